@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/qinyuanmao/gcash-deeplink/auth"
+	"github.com/qinyuanmao/gcash-deeplink/httperr"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	"github.com/qinyuanmao/gcash-deeplink/openapi"
+	"github.com/qinyuanmao/gcash-deeplink/webhook"
+)
+
+// apiVersion /v1 API 版本，写入 OpenAPI 文档
+const apiVersion = "1.0.0"
+
+// apiRoute 同时注册在 /api 与 /v1 下的接口
+type apiRoute struct {
+	path    string     // 不含前缀，如 /parse、/orders/
+	scope   auth.Scope // 需要的 API Key scope，空表示无需认证
+	handler http.HandlerFunc
+	docs    []openapi.Operation // /v1 接口文档（每个 HTTP 方法一项）
+}
+
+// 错误状态码
+var (
+	errsBody   = []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusTooManyRequests}
+	errsSecure = []int{http.StatusUnauthorized, http.StatusForbidden}
+)
+
+func errorStatuses(groups ...[]int) []int {
+	var codes []int
+	for _, g := range groups {
+		codes = append(codes, g...)
+	}
+	return codes
+}
+
+// apiRoutes /api 与 /v1 共用的接口列表
+func apiRoutes() []apiRoute {
+	return []apiRoute{
+		{
+			path: "/parse", scope: auth.ScopeParse, handler: handleParse,
+			docs: []openapi.Operation{{
+				Method: http.MethodPost, ID: "parse", Tag: "qr", Summary: "解析 EMVCo QR Code",
+				Request: models.ParseRequest{}, Response: models.ParseResponse{},
+				Errors: errorStatuses(errsBody, errsSecure),
+			}},
+		},
+		{
+			path: "/validate", scope: auth.ScopeParse, handler: handleValidate,
+			docs: []openapi.Operation{{
+				Method: http.MethodPost, ID: "validate", Tag: "qr", Summary: "验证 QR Code（格式、CRC、必填字段）",
+				Request: models.ValidateRequest{}, Response: models.ValidationResult{},
+				Errors: errorStatuses(errsBody, errsSecure),
+			}},
+		},
+		{
+			path: "/generate", scope: auth.ScopeGenerate, handler: handleGenerate,
+			docs: []openapi.Operation{{
+				Method: http.MethodPost, ID: "generate", Tag: "deeplink", Summary: "生成 GCash Deep Link",
+				Request: models.GenerateRequest{}, Response: models.GenerateResponse{},
+				Errors: errorStatuses(errsBody, errsSecure, []int{http.StatusConflict}),
+			}},
+		},
+		{
+			path: "/generate/strategies", scope: auth.ScopeGenerate, handler: handleGenerateStrategies,
+			docs: []openapi.Operation{
+				{
+					Method: http.MethodGet, ID: "listStrategies", Tag: "deeplink", Summary: "查看已配置的生成策略",
+					Response: models.StrategiesResponse{}, Errors: errsSecure,
+				},
+				{
+					Method: http.MethodPost, ID: "generateStrategies", Tag: "deeplink", Summary: "按策略集批量生成 Deep Link",
+					Request: models.GenerateStrategiesRequest{}, Response: models.GenerateStrategiesResponse{},
+					Errors: errorStatuses(errsBody, errsSecure),
+				},
+			},
+		},
+		{
+			path: "/profiles", scope: auth.ScopeParse, handler: handleProfiles,
+			docs: []openapi.Operation{{
+				Method: http.MethodGet, ID: "listProfiles", Tag: "config", Summary: "查看可用的参数布局",
+				Response: models.ProfilesResponse{}, Errors: errsSecure,
+			}},
+		},
+		{
+			path: "/banks", scope: auth.ScopeParse, handler: handleBanks,
+			docs: []openapi.Operation{{
+				Method: http.MethodGet, ID: "listBanks", Tag: "config", Summary: "查看 QR Ph 机构目录；指定 bic 时返回 BankResponse",
+				Params:   []openapi.Parameter{{Name: "bic", In: "query", Description: "按 BIC 查询单个机构"}},
+				Response: models.BanksResponse{}, Errors: errorStatuses(errsSecure, []int{http.StatusNotFound}),
+			}},
+		},
+		{
+			path: "/orders/", scope: auth.ScopeGenerate, handler: handleOrder,
+			docs: []openapi.Operation{{
+				Method: http.MethodGet, Path: "/v1/orders/{orderId}", ID: "getOrder", Tag: "orders", Summary: "查询订单状态",
+				Params:   []openapi.Parameter{{Name: "orderId", In: "path", Description: "生成时指定的订单号"}},
+				Response: models.OrderResponse{}, Errors: errorStatuses(errsSecure, []int{http.StatusNotFound}),
+			}},
+		},
+		{
+			path: "/webhooks/dead-letters", scope: auth.ScopeAdmin, handler: handleDeadLetters,
+			docs: []openapi.Operation{{
+				Method: http.MethodGet, ID: "listDeadLetters", Tag: "webhooks", Summary: "查看投递失败的 Webhook 事件（死信）",
+				Response: webhook.DeadLettersResponse{}, Errors: errorStatuses(errsSecure, []int{http.StatusNotFound}),
+			}},
+		},
+		{
+			path: "/webhooks/dead-letters/", scope: auth.ScopeAdmin, handler: handleDeadLetters,
+			docs: []openapi.Operation{{
+				Method: http.MethodPost, Path: "/v1/webhooks/dead-letters/{id}/replay", ID: "replayDeadLetter", Tag: "webhooks", Summary: "重新投递死信",
+				Params:   []openapi.Parameter{{Name: "id", In: "path", Description: "死信的投递 ID"}},
+				Response: webhook.ReplayResponse{}, Status: http.StatusAccepted,
+				Errors: errorStatuses(errsSecure, []int{http.StatusNotFound, http.StatusServiceUnavailable}),
+			}},
+		},
+	}
+}
+
+// openAPIDocument 由接口列表生成 /v1 的 OpenAPI 文档
+func openAPIDocument(routes []apiRoute) openapi.Document {
+	var ops []openapi.Operation
+	for _, route := range routes {
+		for _, op := range route.docs {
+			if op.Path == "" {
+				op.Path = "/v1" + route.path
+			}
+			if route.scope != "" {
+				op.Scope = string(route.scope)
+			}
+			ops = append(ops, op)
+		}
+	}
+	return openapi.Build(openapi.Info{
+		Title:       "GCash Deep Link API",
+		Version:     apiVersion,
+		Description: "解析 EMVCo / QR Ph QR Code 并生成 GCash Deep Link。错误响应统一为 ErrorResponse，客户端应按 error.code 判断。",
+	}, ops, models.ErrorResponse{})
+}
+
+// openAPIHandler GET /v1/openapi.json
+func openAPIHandler(doc openapi.Document) http.Handler {
+	body, err := json.MarshalIndent(doc, "", "  ")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			httperr.MethodNotAllowed(w, r, http.MethodGet)
+			return
+		}
+		if err != nil {
+			httperr.Write(w, r, http.StatusInternalServerError, models.CodeInternal, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+}
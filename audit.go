@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/qinyuanmao/gcash-deeplink/audit"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// runAuditCommand 审计日志子命令
+//
+//	audit verify
+//	audit search [-order ID] [-merchant ID] [-qr QR|HASH] [-actor KEY_ID] [-since T] [-until T] [-limit N]
+func runAuditCommand(args []string) error {
+	fs := flag.NewFlagSet("audit", flag.ContinueOnError)
+	dir := fs.String("dir", appConfig.AuditDir, "审计日志目录（默认使用配置中的 auditDir）")
+	order := fs.String("order", "", "订单号（search）")
+	merchant := fs.String("merchant", "", "merchantId（search）")
+	qr := fs.String("qr", "", "QR Code 原始数据或其 SHA-256（search）")
+	actor := fs.String("actor", "", "API Key ID（search）")
+	requestID := fs.String("request", "", "请求 ID（search）")
+	since := fs.String("since", "", "起始时间 RFC 3339，含（search）")
+	until := fs.String("until", "", "截止时间 RFC 3339，不含（search）")
+	limit := fs.Int("limit", 0, "最多输出条数，0 不限（search）")
+
+	if len(args) == 0 {
+		return errors.New("用法: audit verify|search [选项]")
+	}
+	action := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *dir == "" {
+		return errors.New("请通过 -dir 或配置 auditDir 指定审计日志目录")
+	}
+
+	switch action {
+	case "verify":
+		result, err := audit.Verify(*dir)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ 哈希链完整: %d 个文件，%d 条记录\n", result.Files, result.Entries)
+		if result.Entries > 0 {
+			fmt.Printf("最后一条: seq %d, hash %s\n", result.LastSeq, result.LastHash)
+		}
+	case "search":
+		filter := audit.Filter{
+			OrderID:    *order,
+			MerchantID: *merchant,
+			Actor:      *actor,
+			RequestID:  *requestID,
+			Limit:      *limit,
+		}
+		if *qr != "" {
+			filter.QRHash = qrHash(*qr)
+		}
+		var err error
+		if filter.Since, err = parseTime(*since); err != nil {
+			return err
+		}
+		if filter.Until, err = parseTime(*until); err != nil {
+			return err
+		}
+		entries, err := audit.Search(*dir, filter)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(os.Stdout)
+		for _, e := range entries {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(os.Stderr, "共 %d 条记录\n", len(entries))
+	default:
+		return fmt.Errorf("未知的 audit 子命令: %s", action)
+	}
+	return nil
+}
+
+// qrHash -qr 可传 QR 原始数据或 64 位十六进制哈希
+func qrHash(s string) string {
+	if len(s) == 64 && isHex(s) {
+		return s
+	}
+	return models.HashValue(s)
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTime 解析 RFC 3339 时间，空字符串为零值
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("时间格式无效（应为 RFC 3339）: %q", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,307 @@
+// Package audit 追加写入的审计日志（JSONL，按大小轮转，SHA-256 哈希链防篡改）
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// DefaultMaxBytes 单个审计文件的默认上限，超出后轮转到新文件
+const DefaultMaxBytes = 10 << 20
+
+// GenesisHash 第一条记录的 PrevHash
+var GenesisHash = strings.Repeat("0", 64)
+
+// ErrChainBroken 哈希链校验失败（记录被修改、删除或插入）
+var ErrChainBroken = errors.New("审计哈希链校验失败")
+
+// 文件名: audit-000001.jsonl，序号递增
+const (
+	filePrefix = "audit-"
+	fileSuffix = ".jsonl"
+)
+
+// Entry 审计日志中的一行
+// Hash = SHA-256(该行去掉末尾 hash 字段后的原始 JSON)，PrevHash 为上一条 Hash
+type Entry struct {
+	Seq  uint64    `json:"seq"`
+	Time time.Time `json:"time"`
+	models.AuditRecord
+	PrevHash string `json:"prevHash"`
+	Hash     string `json:"hash"`
+}
+
+// hashField hash 字段在行中的前缀，Hash 为最后一个字段
+const hashField = `,"hash":"`
+
+// marshalLine 序列化一行并计算哈希，返回带 hash 字段的行与哈希值
+func (e Entry) marshalLine() ([]byte, string, error) {
+	e.Hash = ""
+	line, err := json.Marshal(e)
+	if err != nil {
+		return nil, "", err
+	}
+	body, ok := stripHash(line)
+	if !ok {
+		return nil, "", errors.New("审计记录格式错误")
+	}
+	hash := hashBody(body)
+	line = append(body[:len(body)-1:len(body)-1], hashField+hash+`"}`...)
+	return line, hash, nil
+}
+
+// stripHash 去掉行末的 hash 字段，返回参与哈希计算的原始内容
+func stripHash(line []byte) ([]byte, bool) {
+	i := bytes.LastIndex(line, []byte(hashField))
+	if i < 0 || !bytes.HasSuffix(line, []byte(`"}`)) || i+len(hashField) > len(line)-2 {
+		return nil, false
+	}
+	body := make([]byte, 0, i+1)
+	return append(append(body, line[:i]...), '}'), true
+}
+
+func hashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// Log 审计日志，实现 models.AuditSink；并发安全
+type Log struct {
+	dir      string
+	maxBytes int64
+
+	mu       sync.Mutex
+	file     *os.File
+	index    int   // 当前文件序号
+	size     int64 // 当前文件大小
+	seq      uint64
+	lastHash string
+	redact   models.RedactionPolicy
+	now      func() time.Time
+}
+
+var _ models.AuditSink = (*Log)(nil)
+
+// Open 打开 dir 下的审计日志（不存在时创建），从最后一条记录继续哈希链
+// maxBytes <= 0 使用 DefaultMaxBytes
+func Open(dir string, maxBytes int64) (*Log, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("创建审计目录失败: %w", err)
+	}
+	l := &Log{dir: dir, maxBytes: maxBytes, lastHash: GenesisHash, now: time.Now, index: 1}
+
+	files, err := listFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) > 0 {
+		last := files[len(files)-1]
+		l.index = last.index
+		entry, err := lastEntry(last.path)
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil && len(files) > 1 {
+			// 最新文件为空（刚轮转），从上一个文件继续
+			if entry, err = lastEntry(files[len(files)-2].path); err != nil {
+				return nil, err
+			}
+		}
+		if entry != nil {
+			l.seq, l.lastHash = entry.Seq, entry.Hash
+		}
+	}
+	if err := l.openFile(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// SetRedaction 设置写入前的脱敏规则，默认 models.DefaultRedactionPolicy
+func (l *Log) SetRedaction(p models.RedactionPolicy) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.redact = p
+}
+
+// Append 追加一条记录（实现 models.AuditSink），脱敏后写入并立即落盘
+func (l *Log) Append(record models.AuditRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return errors.New("审计日志已关闭")
+	}
+
+	record = record.Redacted(l.redact)
+	record.GeneratedAt = record.GeneratedAt.UTC()
+	entry := Entry{
+		Seq:         l.seq + 1,
+		Time:        l.now().UTC(),
+		AuditRecord: record,
+		PrevHash:    l.lastHash,
+	}
+	line, hash, err := entry.marshalLine()
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+	line = append(line, '\n')
+
+	if l.size > 0 && l.size+int64(len(line)) > l.maxBytes {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	if _, err := l.file.Write(line); err != nil {
+		return fmt.Errorf("写入审计日志失败: %w", err)
+	}
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("写入审计日志失败: %w", err)
+	}
+	l.size += int64(len(line))
+	l.seq, l.lastHash = entry.Seq, entry.Hash
+	return nil
+}
+
+// Close 关闭当前文件
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// rotate 关闭当前文件并开始下一个文件
+func (l *Log) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	l.index++
+	return l.openFile()
+}
+
+// openFile 以追加方式打开当前序号的文件（只写 0600）
+func (l *Log) openFile() error {
+	f, err := os.OpenFile(filepath.Join(l.dir, fileName(l.index)), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("打开审计日志失败: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	l.file, l.size = f, info.Size()
+	return nil
+}
+
+// Attribute 返回为每条记录填充调用方与请求 ID 的 AuditSink
+func Attribute(sink models.AuditSink, actor, requestID string) models.AuditSink {
+	return models.AuditSinkFunc(func(record models.AuditRecord) error {
+		record.Actor, record.RequestID = actor, requestID
+		return sink.Append(record)
+	})
+}
+
+func fileName(index int) string {
+	return fmt.Sprintf("%s%06d%s", filePrefix, index, fileSuffix)
+}
+
+// auditFile 目录中的审计文件
+type auditFile struct {
+	index int
+	path  string
+}
+
+// listFiles 按序号列出审计文件
+func listFiles(dir string) ([]auditFile, error) {
+	names, err := filepath.Glob(filepath.Join(dir, filePrefix+"*"+fileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	var files []auditFile
+	for _, path := range names {
+		var index int
+		if _, err := fmt.Sscanf(filepath.Base(path), filePrefix+"%06d"+fileSuffix, &index); err != nil || index <= 0 {
+			continue
+		}
+		files = append(files, auditFile{index: index, path: path})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].index < files[j].index })
+	return files, nil
+}
+
+// lastEntry 文件中的最后一条记录，空文件返回 nil
+func lastEntry(path string) (*Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimRight(data, "\n")
+	if len(data) == 0 {
+		return nil, nil
+	}
+	line := data[bytes.LastIndexByte(data, '\n')+1:]
+	var entry Entry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return nil, fmt.Errorf("%s: 最后一条记录无效: %w", filepath.Base(path), err)
+	}
+	return &entry, nil
+}
+
+// scan 按顺序读取 dir 下全部记录；fn 返回错误时停止
+// raw 为该行的原始内容，仅在 fn 内有效
+func scan(dir string, fn func(file string, line int, raw []byte, entry *Entry) error) error {
+	files, err := listFiles(dir)
+	if err != nil {
+		return err
+	}
+	for _, af := range files {
+		if err := scanFile(af.path, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanFile(path string, fn func(file string, line int, raw []byte, entry *Entry) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	name := filepath.Base(path)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16<<20)
+	for line := 1; scanner.Scan(); line++ {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return fmt.Errorf("%w: %s 第 %d 行无法解析: %v", ErrChainBroken, name, line, err)
+		}
+		if err := fn(name, line, scanner.Bytes(), &entry); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
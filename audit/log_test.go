@@ -0,0 +1,86 @@
+package audit_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/audit"
+	"github.com/qinyuanmao/gcash-deeplink/generator"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+func TestAuditTrail(t *testing.T) {
+	qrCode := "00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275"
+	dir := t.TempDir()
+
+	// 文件上限很小，每条记录都会轮转
+	log, err := audit.Open(dir, 512)
+	if err != nil {
+		t.Fatalf("打开审计日志失败: %v", err)
+	}
+	generate := func(sink models.AuditSink, orderID string) error {
+		g := generator.NewDeepLinkGenerator()
+		g.SetAuditSink(audit.Attribute(sink, "key_1", "req-"+orderID))
+		_, err := g.GenerateWithValidation(qrCode, &models.DeepLinkOptions{OrderID: orderID, MerchantID: "M-1"})
+		return err
+	}
+	for _, id := range []string{"A-1", "A-2"} {
+		if err := generate(log, id); err != nil {
+			t.Fatalf("生成失败: %v", err)
+		}
+	}
+	log.Close()
+
+	// 重新打开后继续哈希链
+	if log, err = audit.Open(dir, 512); err != nil {
+		t.Fatal(err)
+	}
+	if err := generate(log, "A-3"); err != nil {
+		t.Fatal(err)
+	}
+	log.Close()
+
+	result, err := audit.Verify(dir)
+	if err != nil {
+		t.Fatalf("校验失败: %v", err)
+	}
+	if result.Entries != 3 || result.Files < 2 || result.LastSeq != 3 {
+		t.Errorf("校验结果错误: %+v", result)
+	}
+
+	entries, err := audit.Search(dir, audit.Filter{OrderID: "A-2"})
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("查询失败: %v %v", entries, err)
+	}
+	e := entries[0]
+	if e.Actor != "key_1" || e.RequestID != "req-A-2" || e.MerchantID != "M-1" || e.QRHash != models.HashValue(qrCode) ||
+		e.Resolved == nil || e.Resolved.OrderID != "A-2" || e.DeepLink != "" || len(e.DeepLinkHash) != 64 {
+		t.Errorf("审计记录错误: %+v", e)
+	}
+	if raw, _ := os.ReadFile(filepath.Join(dir, "audit-000001.jsonl")); bytes.Contains(raw, []byte(qrCode)) || bytes.Contains(raw, []byte("gcash://")) {
+		t.Errorf("审计日志不应包含 QR 原始数据或 Deep Link: %s", raw)
+	}
+	if entries, _ := audit.Search(dir, audit.Filter{QRHash: models.HashValue(qrCode), Limit: 2}); len(entries) != 2 {
+		t.Errorf("按 QR 哈希查询应返回 2 条, got %d", len(entries))
+	}
+
+	// 篡改任意一条记录后校验失败
+	files, _ := filepath.Glob(filepath.Join(dir, "audit-*.jsonl"))
+	first, _ := os.ReadFile(files[0])
+	// 插入解码时会被忽略的字段同样视为篡改（哈希按原始字节计算）
+	os.WriteFile(files[0], bytes.Replace(first, []byte(`{"seq":1,`), []byte(`{"seq":1,"note":"x",`), 1), 0o600)
+	if _, err := audit.Verify(dir); !errors.Is(err, audit.ErrChainBroken) {
+		t.Errorf("插入字段后应校验失败, got %v", err)
+	}
+	os.WriteFile(files[0], bytes.Replace(first, []byte(`"orderId":"A-1"`), []byte(`"orderId":"A-9"`), 1), 0o600)
+	if _, err := audit.Verify(dir); !errors.Is(err, audit.ErrChainBroken) {
+		t.Errorf("篡改后应校验失败, got %v", err)
+	}
+	os.WriteFile(files[0], nil, 0o600)
+	if _, err := audit.Verify(dir); !errors.Is(err, audit.ErrChainBroken) {
+		t.Errorf("删除记录后应校验失败, got %v", err)
+	}
+}
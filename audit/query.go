@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// VerifyResult 哈希链校验结果
+type VerifyResult struct {
+	Entries  int    // 记录数
+	Files    int    // 文件数
+	LastSeq  uint64 // 最后一条记录序号
+	LastHash string // 最后一条记录哈希（可另行保存，用于发现整体截断）
+}
+
+// Verify 校验 dir 下全部记录的序号连续性与哈希链
+// 失败时返回 ErrChainBroken 及出错的文件与行号
+func Verify(dir string) (*VerifyResult, error) {
+	result := &VerifyResult{LastHash: GenesisHash}
+	files, err := listFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	result.Files = len(files)
+
+	err = scan(dir, func(file string, line int, raw []byte, e *Entry) error {
+		if e.Seq != result.LastSeq+1 {
+			return fmt.Errorf("%w: %s 第 %d 行序号为 %d，应为 %d", ErrChainBroken, file, line, e.Seq, result.LastSeq+1)
+		}
+		if e.PrevHash != result.LastHash {
+			return fmt.Errorf("%w: %s 第 %d 行 (seq %d) 与上一条记录不连续", ErrChainBroken, file, line, e.Seq)
+		}
+		// 按文件中的原始字节计算，不依赖重新序列化
+		body, ok := stripHash(raw)
+		if !ok || hashBody(body) != e.Hash {
+			return fmt.Errorf("%w: %s 第 %d 行 (seq %d) 内容已被修改", ErrChainBroken, file, line, e.Seq)
+		}
+		result.Entries++
+		result.LastSeq, result.LastHash = e.Seq, e.Hash
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Filter 查询条件，空字段不限
+type Filter struct {
+	OrderID    string
+	MerchantID string
+	QRHash     string
+	Actor      string
+	RequestID  string
+	Since      time.Time
+	Until      time.Time
+	Limit      int // 最多返回条数，<= 0 不限
+}
+
+// Match 记录是否满足条件
+func (f Filter) Match(e *Entry) bool {
+	switch {
+	case f.OrderID != "" && e.OrderID != f.OrderID,
+		f.MerchantID != "" && e.MerchantID != f.MerchantID,
+		f.QRHash != "" && e.QRHash != f.QRHash,
+		f.Actor != "" && e.Actor != f.Actor,
+		f.RequestID != "" && e.RequestID != f.RequestID,
+		!f.Since.IsZero() && e.Time.Before(f.Since),
+		!f.Until.IsZero() && !e.Time.Before(f.Until):
+		return false
+	}
+	return true
+}
+
+// errLimit 达到 Limit 时停止扫描
+var errLimit = errors.New("limit")
+
+// Search 按时间顺序返回满足条件的记录（不校验哈希链，需要时先调用 Verify）
+func Search(dir string, filter Filter) ([]Entry, error) {
+	var entries []Entry
+	err := scan(dir, func(file string, line int, _ []byte, e *Entry) error {
+		if filter.Match(e) {
+			entries = append(entries, *e)
+			if filter.Limit > 0 && len(entries) >= filter.Limit {
+				return errLimit
+			}
+		}
+		return nil
+	})
+	if err != nil && err != errLimit {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,300 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 认证相关错误，可通过 errors.Is 判断
+var (
+	ErrMissingKey     = errors.New("缺少 API Key")
+	ErrInvalidKey     = errors.New("API Key 无效")
+	ErrScopeDenied    = errors.New("API Key 无权访问该接口")
+	ErrMerchantDenied = errors.New("API Key 无权操作该商户")
+	ErrKeyNotFound    = errors.New("API Key 不存在")
+)
+
+// Scope 权限范围
+type Scope string
+
+const (
+	ScopeParse    Scope = "parse"    // 解析 / 验证 QR Code
+	ScopeGenerate Scope = "generate" // 生成 Deep Link、查询订单
+	ScopeMetrics  Scope = "metrics"  // 采集 /metrics 指标
+	ScopeAdmin    Scope = "admin"    // 管理接口，包含全部权限
+)
+
+// Scopes 全部权限范围
+var Scopes = []Scope{ScopeParse, ScopeGenerate, ScopeMetrics, ScopeAdmin}
+
+// tokenPrefix API Key 前缀，便于识别泄露的密钥
+const tokenPrefix = "gdl_"
+
+// Key API Key 记录；文件中只保存密钥的 SHA-256 摘要
+type Key struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Hash      string    `json:"hash"`
+	Scopes    []Scope   `json:"scopes"`
+	Merchants []string  `json:"merchants,omitempty"` // 为空表示不限商户
+	CreatedAt time.Time `json:"createdAt"`
+	Revoked   bool      `json:"revoked,omitempty"`
+}
+
+// HasScope 是否拥有权限（admin 包含全部权限）
+func (k *Key) HasScope(scope Scope) bool {
+	for _, s := range k.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsMerchant 是否允许操作该商户
+func (k *Key) AllowsMerchant(merchantID string) bool {
+	if len(k.Merchants) == 0 {
+		return true
+	}
+	for _, m := range k.Merchants {
+		if m == merchantID {
+			return true
+		}
+	}
+	return false
+}
+
+// keyFile 密钥文件格式
+type keyFile struct {
+	Keys []*Key `json:"keys"`
+}
+
+// KeyStore API Key 存储，基于本地 JSON 文件，并发安全
+type KeyStore struct {
+	path string
+
+	mu      sync.RWMutex
+	keys    []*Key
+	byHash  map[string]*Key
+	modTime time.Time // 最近一次读取或写入时文件的修改时间，用于 ReloadIfChanged
+}
+
+// LoadKeyStore 读取密钥文件；文件不存在时返回空存储（首次添加时创建）
+func LoadKeyStore(path string) (*KeyStore, error) {
+	s := &KeyStore{path: path, byHash: make(map[string]*Key)}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload 重新读取密钥文件（如 keys revoke 在其他进程中修改了文件）
+// 读取或解析失败时保留当前密钥并返回错误；文件不存在时清空
+func (s *KeyStore) Reload() error {
+	info, err := os.Stat(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.mu.Lock()
+		s.keys, s.byHash, s.modTime = nil, make(map[string]*Key), time.Time{}
+		s.mu.Unlock()
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("读取密钥文件失败: %w", err)
+	}
+	raw, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("读取密钥文件失败: %w", err)
+	}
+
+	var f keyFile
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return fmt.Errorf("密钥文件格式错误: %w", err)
+	}
+	byHash := make(map[string]*Key, len(f.Keys))
+	for _, k := range f.Keys {
+		if err := validateScopes(k.Scopes); err != nil {
+			return fmt.Errorf("API Key %s: %w", k.ID, err)
+		}
+		byHash[k.Hash] = k
+	}
+
+	s.mu.Lock()
+	s.keys, s.byHash, s.modTime = f.Keys, byHash, info.ModTime()
+	s.mu.Unlock()
+	return nil
+}
+
+// ReloadIfChanged 文件修改时间变化时重新读取，返回是否已重新读取
+func (s *KeyStore) ReloadIfChanged() (bool, error) {
+	var modTime time.Time
+	info, err := os.Stat(s.path)
+	switch {
+	case err == nil:
+		modTime = info.ModTime()
+	case !errors.Is(err, os.ErrNotExist):
+		return false, fmt.Errorf("读取密钥文件失败: %w", err)
+	}
+
+	s.mu.RLock()
+	changed := !modTime.Equal(s.modTime)
+	s.mu.RUnlock()
+	if !changed {
+		return false, nil
+	}
+	if err := s.Reload(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Authenticate 校验密钥，返回对应的 Key
+func (s *KeyStore) Authenticate(token string) (*Key, error) {
+	if token == "" {
+		return nil, ErrMissingKey
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	k, ok := s.byHash[hashToken(token)]
+	if !ok || k.Revoked {
+		return nil, ErrInvalidKey
+	}
+	return k, nil
+}
+
+// Create 创建密钥并写回文件；明文密钥只在此返回一次
+func (s *KeyStore) Create(name string, scopes []Scope, merchants []string) (token string, key *Key, err error) {
+	if err := validateScopes(scopes); err != nil {
+		return "", nil, err
+	}
+	if len(scopes) == 0 {
+		return "", nil, errors.New("至少需要一个 scope")
+	}
+	id, err := randomHex(6)
+	if err != nil {
+		return "", nil, err
+	}
+	secret, err := randomHex(24)
+	if err != nil {
+		return "", nil, err
+	}
+	token = tokenPrefix + id + "_" + secret
+
+	key = &Key{
+		ID:        "key_" + id,
+		Name:      name,
+		Hash:      hashToken(token),
+		Scopes:    scopes,
+		Merchants: merchants,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = append(s.keys, key)
+	s.byHash[key.Hash] = key
+	if err := s.save(); err != nil {
+		return "", nil, err
+	}
+	return token, key, nil
+}
+
+// Revoke 吊销密钥并写回文件
+func (s *KeyStore) Revoke(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, k := range s.keys {
+		if k.ID == id {
+			k.Revoked = true
+			return s.save()
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrKeyNotFound, id)
+}
+
+// Merchants 未吊销密钥限定的商户（去重）
+func (s *KeyStore) Merchants() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	seen := make(map[string]bool)
+	var merchants []string
+	for _, k := range s.keys {
+		for _, m := range k.Merchants {
+			if !k.Revoked && !seen[m] {
+				seen[m] = true
+				merchants = append(merchants, m)
+			}
+		}
+	}
+	return merchants
+}
+
+// List 返回全部密钥（按创建时间排序）
+func (s *KeyStore) List() []Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]Key, 0, len(s.keys))
+	for _, k := range s.keys {
+		list = append(list, *k)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list
+}
+
+// save 原子写回文件（权限 0600），调用方需持有写锁
+func (s *KeyStore) save() error {
+	raw, err := json.MarshalIndent(keyFile{Keys: s.keys}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("写入密钥文件失败: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return err
+	}
+	if info, err := os.Stat(s.path); err == nil {
+		s.modTime = info.ModTime()
+	}
+	return nil
+}
+
+// ParseScope 解析权限范围
+func ParseScope(s string) (Scope, error) {
+	for _, scope := range Scopes {
+		if string(scope) == s {
+			return scope, nil
+		}
+	}
+	return "", fmt.Errorf("未知的 scope: %q", s)
+}
+
+func validateScopes(scopes []Scope) error {
+	for _, s := range scopes {
+		if _, err := ParseScope(string(s)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
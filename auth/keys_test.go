@@ -0,0 +1,83 @@
+package auth_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qinyuanmao/gcash-deeplink/auth"
+)
+
+func TestKeyStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	keys, err := auth.LoadKeyStore(path)
+	if err != nil {
+		t.Fatalf("加载密钥文件失败: %v", err)
+	}
+	shopA, _, err := keys.Create("shop-a", []auth.Scope{auth.ScopeGenerate}, []string{"M-A"})
+	if err != nil {
+		t.Fatalf("创建 Key 失败: %v", err)
+	}
+	parseOnly, _, _ := keys.Create("parser", []auth.Scope{auth.ScopeParse}, nil)
+
+	// 重新加载：文件中只有摘要
+	keys, err = auth.LoadKeyStore(path)
+	if err != nil || len(keys.List()) != 2 {
+		t.Fatalf("重新加载失败: %v", err)
+	}
+	if raw, _ := os.ReadFile(path); strings.Contains(string(raw), shopA) {
+		t.Error("密钥文件不应包含明文密钥")
+	}
+	k, err := keys.Authenticate(shopA)
+	if err != nil || !k.HasScope(auth.ScopeGenerate) || k.HasScope(auth.ScopeParse) || !k.AllowsMerchant("M-A") || k.AllowsMerchant("M-B") {
+		t.Errorf("Key 权限错误: %+v %v", k, err)
+	}
+	if _, err := keys.Authenticate(""); !errors.Is(err, auth.ErrMissingKey) {
+		t.Errorf("缺少 Key 应返回 ErrMissingKey, got %v", err)
+	}
+
+	// 吊销后失效
+	if err := keys.Revoke(keys.List()[0].ID); err != nil {
+		t.Fatalf("吊销失败: %v", err)
+	}
+	if _, err := keys.Authenticate(shopA); !errors.Is(err, auth.ErrInvalidKey) {
+		t.Errorf("吊销后应返回 ErrInvalidKey, got %v", err)
+	}
+
+	// 其他进程（keys revoke）修改文件后，运行中的存储按修改时间重新加载
+	cli, err := auth.LoadKeyStore(path)
+	if err != nil {
+		t.Fatalf("加载密钥文件失败: %v", err)
+	}
+	if err := cli.Revoke(keys.List()[1].ID); err != nil {
+		t.Fatalf("吊销失败: %v", err)
+	}
+	// 避免文件系统时间精度不足导致修改时间不变
+	later := time.Now().Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keys.Authenticate(parseOnly); err != nil {
+		t.Fatalf("重新加载前仍应有效: %v", err)
+	}
+	if reloaded, err := keys.ReloadIfChanged(); err != nil || !reloaded {
+		t.Fatalf("文件变更后应重新加载: reloaded=%v err=%v", reloaded, err)
+	}
+	if _, err := keys.Authenticate(parseOnly); !errors.Is(err, auth.ErrInvalidKey) {
+		t.Errorf("重新加载后吊销的 Key 应失效, got %v", err)
+	}
+	if reloaded, _ := keys.ReloadIfChanged(); reloaded {
+		t.Error("文件未变更时不应重新加载")
+	}
+
+	// 文件格式错误时保留已加载的密钥
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := keys.Reload(); err == nil || len(keys.List()) != 2 {
+		t.Errorf("格式错误应返回错误并保留密钥: err=%v keys=%d", err, len(keys.List()))
+	}
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/qinyuanmao/gcash-deeplink/httperr"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// HeaderAPIKey API Key 请求头（也可使用 Authorization: Bearer）
+const HeaderAPIKey = "X-API-Key"
+
+type contextKey struct{}
+
+// FromContext 返回请求携带的 Key；未启用认证时为 nil
+func FromContext(ctx context.Context) *Key {
+	k, _ := ctx.Value(contextKey{}).(*Key)
+	return k
+}
+
+// WithKey 将 Key 写入 context
+func WithKey(ctx context.Context, k *Key) context.Context {
+	return context.WithValue(ctx, contextKey{}, k)
+}
+
+// Require 要求请求携带拥有 scope 权限的 API Key；store 为 nil 时不校验（未启用认证）
+func Require(store *KeyStore, scope Scope, next http.Handler) http.Handler {
+	if store == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		k, err := store.Authenticate(TokenFromRequest(r))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="gcash-deeplink"`)
+			httperr.Write(w, r, http.StatusUnauthorized, models.CodeUnauthorized, err.Error())
+			return
+		}
+		if !k.HasScope(scope) {
+			httperr.Write(w, r, http.StatusForbidden, models.CodeForbidden, ErrScopeDenied.Error())
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithKey(r.Context(), k)))
+	})
+}
+
+// TokenFromRequest 从 Authorization: Bearer 或 X-API-Key 读取密钥
+func TokenFromRequest(r *http.Request) string {
+	if h := r.Header.Get("Authorization"); h != "" {
+		if token, ok := strings.CutPrefix(h, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+	return strings.TrimSpace(r.Header.Get(HeaderAPIKey))
+}
+
+// CheckMerchant 校验请求的 Key 是否允许操作该商户；未启用认证时总是允许
+func CheckMerchant(ctx context.Context, merchantID string) error {
+	k := FromContext(ctx)
+	if k == nil || k.AllowsMerchant(merchantID) {
+		return nil
+	}
+	if merchantID == "" {
+		return fmt.Errorf("%w: 受限的 API Key 必须指定 merchantId", ErrMerchantDenied)
+	}
+	return fmt.Errorf("%w: %s", ErrMerchantDenied, merchantID)
+}
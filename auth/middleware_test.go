@@ -0,0 +1,62 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/auth"
+)
+
+func TestRequire(t *testing.T) {
+	keys, err := auth.LoadKeyStore(filepath.Join(t.TempDir(), "keys.json"))
+	if err != nil {
+		t.Fatalf("加载密钥文件失败: %v", err)
+	}
+	shopA, _, _ := keys.Create("shop-a", []auth.Scope{auth.ScopeGenerate}, []string{"M-A"})
+	parseOnly, _, _ := keys.Create("parser", []auth.Scope{auth.ScopeParse}, nil)
+
+	handler := auth.Require(keys, auth.ScopeGenerate, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := auth.CheckMerchant(r.Context(), r.URL.Query().Get("merchantId")); err != nil {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	tests := []struct {
+		name, header, token, merchantID string
+		want                            int
+	}{
+		{"缺少 Key", "", "", "M-A", http.StatusUnauthorized},
+		{"无效 Key", "Authorization", "Bearer gdl_bogus", "M-A", http.StatusUnauthorized},
+		{"scope 不足", auth.HeaderAPIKey, parseOnly, "M-A", http.StatusForbidden},
+		{"其他商户", "Authorization", "Bearer " + shopA, "M-B", http.StatusForbidden},
+		{"未指定商户", auth.HeaderAPIKey, shopA, "", http.StatusForbidden},
+		{"允许的商户", "Authorization", "Bearer " + shopA, "M-A", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/?merchantId="+tt.merchantID, nil)
+		if tt.header != "" {
+			req.Header.Set(tt.header, tt.token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s: 状态码 %d, want %d", tt.name, rec.Code, tt.want)
+		}
+		if tt.want == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("%s: 401 应返回 WWW-Authenticate", tt.name)
+		}
+	}
+
+	// 未启用认证时不校验
+	open := auth.Require(nil, auth.ScopeAdmin, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := auth.CheckMerchant(r.Context(), "M-B"); err != nil {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	rec := httptest.NewRecorder()
+	open.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("未启用认证时应允许任意商户, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,489 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/qinyuanmao/gcash-deeplink/generator"
+	"github.com/qinyuanmao/gcash-deeplink/logging"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	"github.com/qinyuanmao/gcash-deeplink/store"
+)
+
+// EnvConfigPath 配置文件路径环境变量（命令行 -config 优先）
+const EnvConfigPath = "GCASH_DEEPLINK_CONFIG"
+
+// Config 服务配置，从 JSON 文件加载
+type Config struct {
+	// Strategies GenerateMultiple 与 /api/generate/strategies 默认使用的策略集
+	Strategies []models.Strategy `json:"strategies,omitempty"`
+
+	// Profiles 参数布局，与内置布局合并（同名覆盖）
+	// DefaultProfile 为未指定时使用的布局
+	Profiles       []models.ParameterProfile `json:"profiles,omitempty"`
+	DefaultProfile string                    `json:"defaultProfile,omitempty"`
+
+	// AmountPolicy 金额上下限（默认 / 按支付类型 / 按商户）
+	AmountPolicy *models.AmountPolicy `json:"amountPolicy,omitempty"`
+
+	// MCCPolicy 商户分类规则（按顺序评估，替换默认规则）
+	MCCPolicy *models.MCCPolicy `json:"mccPolicy,omitempty"`
+
+	// NotifySecret GCash 支付通知 HMAC-SHA256 签名密钥；未配置时 /api/gcash/notify 拒绝所有通知
+	// NotifySignatureHeader 签名请求头，默认 X-GCash-Signature
+	NotifySecret          string `json:"notifySecret,omitempty"`
+	NotifySignatureHeader string `json:"notifySignatureHeader,omitempty"`
+
+	// URLPolicy redirectUrl / notifyUrl 主机允许列表（默认 / 按商户）
+	// PublicBaseURL 本服务对外地址，redirectUrl 含 {status} 时经 /return/{orderId} 跳转
+	URLPolicy     *models.URLPolicy `json:"urlPolicy,omitempty"`
+	PublicBaseURL string            `json:"publicBaseUrl,omitempty"`
+
+	// OrderTTL 订单有效期（Go duration，如 "15m"），超时未支付转为 expired；"0" 表示不过期
+	OrderTTL string `json:"orderTtl,omitempty"`
+	// OrderRetention 已支付 / 失败 / 过期订单的保留时间，超过后从内存移除；"0" 表示永久保留
+	OrderRetention string `json:"orderRetention,omitempty"`
+
+	// Webhooks 事件订阅（按商户），推送 link.generated / link.opened / payment.succeeded / payment.failed
+	Webhooks []models.WebhookSubscription `json:"webhooks,omitempty"`
+	// WebhookDeadLetterFile 死信持久化文件，死信增减时立即写入、启动时恢复；为空时死信在退出后丢失
+	WebhookDeadLetterFile string `json:"webhookDeadLetterFile,omitempty"`
+
+	// APIKeysFile API Key 文件（由 keys 子命令管理）；未配置时不启用认证
+	// CORSOrigins 允许跨域的来源；未配置时仅在未启用认证时允许 "*"
+	APIKeysFile string   `json:"apiKeysFile,omitempty"`
+	CORSOrigins []string `json:"corsOrigins,omitempty"`
+
+	// Limits 请求限流、大小与超时
+	Limits Limits `json:"limits"`
+
+	// AuditDir 审计日志目录（JSONL，哈希链）；未配置时不记录审计日志
+	// AuditMaxBytes 单个审计文件上限，超出后轮转，默认 10 MiB
+	AuditDir      string `json:"auditDir,omitempty"`
+	AuditMaxBytes int64  `json:"auditMaxBytes,omitempty"`
+
+	// LogLevel 日志级别 debug / info / warn / error，默认 info（JSON 格式输出到 stderr）
+	// Redaction 日志与 Webhook 事件的脱敏规则（QR 数据默认 hash，收款账号与手机号默认 mask）
+	LogLevel  string                  `json:"logLevel,omitempty"`
+	Redaction *models.RedactionPolicy `json:"redaction,omitempty"`
+
+	// ListenAddr 监听地址，默认 :9000；TLSCertFile / TLSKeyFile 同时配置时启用 HTTPS
+	// ShutdownTimeout 收到 SIGTERM / SIGINT 后等待处理中请求完成的最长时间，默认 30s
+	ListenAddr      string `json:"listenAddr,omitempty"`
+	TLSCertFile     string `json:"tlsCertFile,omitempty"`
+	TLSKeyFile      string `json:"tlsKeyFile,omitempty"`
+	ShutdownTimeout string `json:"shutdownTimeout,omitempty"`
+
+	// PublicDir Web 界面目录，覆盖编译进程序的 public/（需包含 index.html）；未配置时使用内置页面
+	PublicDir string `json:"publicDir,omitempty"`
+
+	// GRPCAddr gRPC 服务监听地址（如 :9090），未配置时不启动；与 HTTP 共用 TLS 证书、API Key 与优雅退出时间
+	GRPCAddr string `json:"grpcAddr,omitempty"`
+
+	// BankDirectoryFile 机构目录 JSON 文件，合并到内置 QR Ph 机构目录（同 BIC 覆盖）
+	BankDirectoryFile string               `json:"bankDirectoryFile,omitempty"`
+	Participants      []models.Participant `json:"-"` // 从 BankDirectoryFile 读取
+}
+
+// Limits HTTP 防护参数；零值表示使用默认值，限流速率为负数表示不限流
+type Limits struct {
+	MaxBodyBytes      int64   `json:"maxBodyBytes,omitempty"`      // 请求体上限，默认 1 MiB
+	IPRate            float64 `json:"ipRate,omitempty"`            // 每个 IP 每秒请求数，默认 10
+	IPBurst           int     `json:"ipBurst,omitempty"`           // 每个 IP 突发上限，默认 20
+	KeyRate           float64 `json:"keyRate,omitempty"`           // 每个 API Key 每秒请求数，默认 50
+	KeyBurst          int     `json:"keyBurst,omitempty"`          // 每个 API Key 突发上限，默认 100
+	MaxConcurrent     int     `json:"maxConcurrent,omitempty"`     // 同时处理的请求数（gRPC 为每个连接的并发流数），默认 100
+	MaxBatch          int     `json:"maxBatch,omitempty"`          // gRPC GenerateBatch 单个流的请求数上限，默认 100
+	TrustProxy        bool    `json:"trustProxy,omitempty"`        // 服务位于一层反向代理之后，等同 trustedProxies: 1
+	TrustedProxies    int     `json:"trustedProxies,omitempty"`    // 服务前可信反向代理的层数，按 X-Forwarded-For 最右侧的地址识别客户端 IP
+	ReadHeaderTimeout string  `json:"readHeaderTimeout,omitempty"` // 默认 5s
+	ReadTimeout       string  `json:"readTimeout,omitempty"`       // 默认 15s
+	WriteTimeout      string  `json:"writeTimeout,omitempty"`      // 默认 30s
+	IdleTimeout       string  `json:"idleTimeout,omitempty"`       // 默认 120s
+}
+
+// DefaultLimits 默认防护参数
+func DefaultLimits() Limits {
+	return Limits{
+		MaxBodyBytes:      1 << 20,
+		IPRate:            10,
+		IPBurst:           20,
+		KeyRate:           50,
+		KeyBurst:          100,
+		MaxConcurrent:     100,
+		MaxBatch:          100,
+		ReadHeaderTimeout: "5s",
+		ReadTimeout:       "15s",
+		WriteTimeout:      "30s",
+		IdleTimeout:       "120s",
+	}
+}
+
+// Timeouts 解析超时设置
+func (l Limits) Timeouts() (readHeader, read, write, idle time.Duration, err error) {
+	durations := []*time.Duration{&readHeader, &read, &write, &idle}
+	for i, s := range []string{l.ReadHeaderTimeout, l.ReadTimeout, l.WriteTimeout, l.IdleTimeout} {
+		if *durations[i], err = time.ParseDuration(s); err != nil || *durations[i] < 0 {
+			return 0, 0, 0, 0, fmt.Errorf("limits 超时设置无效: %q", s)
+		}
+	}
+	return readHeader, read, write, idle, nil
+}
+
+// ProxyHops 可信反向代理层数；trustProxy 视为一层
+func (l Limits) ProxyHops() int {
+	if l.TrustedProxies <= 0 && l.TrustProxy {
+		return 1
+	}
+	return l.TrustedProxies
+}
+
+// merge 用 other 中的非零值覆盖
+func (l Limits) merge(other Limits) Limits {
+	if other.MaxBodyBytes != 0 {
+		l.MaxBodyBytes = other.MaxBodyBytes
+	}
+	if other.IPRate != 0 {
+		l.IPRate = other.IPRate
+	}
+	if other.IPBurst != 0 {
+		l.IPBurst = other.IPBurst
+	}
+	if other.KeyRate != 0 {
+		l.KeyRate = other.KeyRate
+	}
+	if other.KeyBurst != 0 {
+		l.KeyBurst = other.KeyBurst
+	}
+	if other.MaxConcurrent != 0 {
+		l.MaxConcurrent = other.MaxConcurrent
+	}
+	if other.MaxBatch != 0 {
+		l.MaxBatch = other.MaxBatch
+	}
+	l.TrustProxy = l.TrustProxy || other.TrustProxy
+	if other.TrustedProxies != 0 {
+		l.TrustedProxies = other.TrustedProxies
+	}
+	if other.ReadHeaderTimeout != "" {
+		l.ReadHeaderTimeout = other.ReadHeaderTimeout
+	}
+	if other.ReadTimeout != "" {
+		l.ReadTimeout = other.ReadTimeout
+	}
+	if other.WriteTimeout != "" {
+		l.WriteTimeout = other.WriteTimeout
+	}
+	if other.IdleTimeout != "" {
+		l.IdleTimeout = other.IdleTimeout
+	}
+	return l
+}
+
+// 服务监听默认值
+const (
+	DefaultListenAddr      = ":9000"
+	DefaultShutdownTimeout = 30 * time.Second
+)
+
+// Default 返回默认配置
+func Default() *Config {
+	policy := models.DefaultAmountPolicy()
+	mccPolicy := models.DefaultMCCPolicy()
+	return &Config{
+		Strategies:      generator.DefaultStrategies(),
+		Profiles:        generator.DefaultProfiles(),
+		DefaultProfile:  generator.DefaultProfileName,
+		AmountPolicy:    &policy,
+		MCCPolicy:       &mccPolicy,
+		OrderTTL:        store.DefaultOrderTTL.String(),
+		OrderRetention:  store.DefaultOrderRetention.String(),
+		Limits:          DefaultLimits(),
+		ListenAddr:      DefaultListenAddr,
+		ShutdownTimeout: DefaultShutdownTimeout.String(),
+	}
+}
+
+// Load 从 JSON 文件加载配置，未设置的部分使用默认值；path 为空时返回默认配置
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if path == "" {
+		return cfg, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+	}
+
+	// amountPolicy 解码到默认策略之上：文件省略 default（或其中的 min / max）时保留默认上下限
+	filePolicy := models.DefaultAmountPolicy()
+	fileCfg := Config{AmountPolicy: &filePolicy}
+	if err := json.Unmarshal(raw, &fileCfg); err != nil {
+		return nil, fmt.Errorf("配置文件格式错误: %w", err)
+	}
+
+	if len(fileCfg.Strategies) > 0 {
+		cfg.Strategies = fileCfg.Strategies
+	}
+	cfg.Profiles = mergeProfiles(cfg.Profiles, fileCfg.Profiles)
+	if fileCfg.DefaultProfile != "" {
+		cfg.DefaultProfile = fileCfg.DefaultProfile
+	}
+	if fileCfg.AmountPolicy != nil {
+		cfg.AmountPolicy = fileCfg.AmountPolicy
+	}
+	if fileCfg.MCCPolicy != nil {
+		cfg.MCCPolicy = fileCfg.MCCPolicy
+	}
+	if fileCfg.URLPolicy != nil {
+		cfg.URLPolicy = fileCfg.URLPolicy
+	}
+	if fileCfg.PublicBaseURL != "" {
+		cfg.PublicBaseURL = fileCfg.PublicBaseURL
+	}
+	if fileCfg.OrderTTL != "" {
+		cfg.OrderTTL = fileCfg.OrderTTL
+	}
+	if fileCfg.OrderRetention != "" {
+		cfg.OrderRetention = fileCfg.OrderRetention
+	}
+	if fileCfg.WebhookDeadLetterFile != "" {
+		cfg.WebhookDeadLetterFile = fileCfg.WebhookDeadLetterFile
+	}
+	if len(fileCfg.Webhooks) > 0 {
+		cfg.Webhooks = fileCfg.Webhooks
+	}
+	cfg.Limits = cfg.Limits.merge(fileCfg.Limits)
+	if fileCfg.APIKeysFile != "" {
+		cfg.APIKeysFile = fileCfg.APIKeysFile
+	}
+	if len(fileCfg.CORSOrigins) > 0 {
+		cfg.CORSOrigins = fileCfg.CORSOrigins
+	}
+	if fileCfg.AuditDir != "" {
+		cfg.AuditDir = fileCfg.AuditDir
+	}
+	if fileCfg.AuditMaxBytes != 0 {
+		cfg.AuditMaxBytes = fileCfg.AuditMaxBytes
+	}
+	if fileCfg.LogLevel != "" {
+		cfg.LogLevel = fileCfg.LogLevel
+	}
+	if fileCfg.Redaction != nil {
+		cfg.Redaction = fileCfg.Redaction
+	}
+	if fileCfg.ListenAddr != "" {
+		cfg.ListenAddr = fileCfg.ListenAddr
+	}
+	if fileCfg.TLSCertFile != "" {
+		cfg.TLSCertFile = fileCfg.TLSCertFile
+	}
+	if fileCfg.TLSKeyFile != "" {
+		cfg.TLSKeyFile = fileCfg.TLSKeyFile
+	}
+	if fileCfg.ShutdownTimeout != "" {
+		cfg.ShutdownTimeout = fileCfg.ShutdownTimeout
+	}
+	if fileCfg.PublicDir != "" {
+		cfg.PublicDir = fileCfg.PublicDir
+	}
+	if fileCfg.GRPCAddr != "" {
+		cfg.GRPCAddr = fileCfg.GRPCAddr
+	}
+	if fileCfg.NotifySecret != "" {
+		cfg.NotifySecret = fileCfg.NotifySecret
+	}
+	if fileCfg.NotifySignatureHeader != "" {
+		cfg.NotifySignatureHeader = fileCfg.NotifySignatureHeader
+	}
+	if fileCfg.BankDirectoryFile != "" {
+		cfg.BankDirectoryFile = fileCfg.BankDirectoryFile
+		if cfg.Participants, err = models.LoadParticipantsFile(fileCfg.BankDirectoryFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate 校验配置
+func (c *Config) Validate() error {
+	if err := ValidateStrategies(c.Strategies); err != nil {
+		return err
+	}
+	if _, err := c.OrderTTLDuration(); err != nil {
+		return err
+	}
+	if _, err := c.OrderRetentionDuration(); err != nil {
+		return err
+	}
+	if err := ValidateWebhooks(c.Webhooks); err != nil {
+		return err
+	}
+	if _, _, _, _, err := c.Limits.Timeouts(); err != nil {
+		return err
+	}
+	if _, err := c.ShutdownTimeoutDuration(); err != nil {
+		return err
+	}
+	if c.AuditMaxBytes < 0 {
+		return fmt.Errorf("auditMaxBytes 不能为负数: %d", c.AuditMaxBytes)
+	}
+	if _, err := logging.ParseLevel(c.LogLevel); err != nil {
+		return err
+	}
+	if c.Redaction != nil {
+		if err := c.Redaction.Validate(); err != nil {
+			return err
+		}
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return fmt.Errorf("tlsCertFile 与 tlsKeyFile 必须同时配置")
+	}
+	if c.PublicDir != "" {
+		if info, err := os.Stat(filepath.Join(c.PublicDir, "index.html")); err != nil || info.IsDir() {
+			return fmt.Errorf("publicDir 中没有 index.html: %q", c.PublicDir)
+		}
+	}
+	if c.PublicBaseURL != "" {
+		if u, err := url.Parse(c.PublicBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("publicBaseUrl 无效: %q", c.PublicBaseURL)
+		}
+	}
+	if c.MCCPolicy != nil {
+		if err := c.MCCPolicy.Validate(); err != nil {
+			return err
+		}
+	}
+	g := generator.NewDeepLinkGenerator()
+	return g.SetProfiles(c.Profiles, c.DefaultProfile)
+}
+
+// Merchants 配置中出现的商户：webhooks、urlPolicy.merchants 与 amountPolicy.merchants
+func (c *Config) Merchants() []string {
+	var merchants []string
+	for _, sub := range c.Webhooks {
+		merchants = append(merchants, sub.MerchantID)
+	}
+	if c.URLPolicy != nil {
+		for id := range c.URLPolicy.Merchants {
+			merchants = append(merchants, id)
+		}
+	}
+	if c.AmountPolicy != nil {
+		for id := range c.AmountPolicy.Merchants {
+			merchants = append(merchants, id)
+		}
+	}
+	return merchants
+}
+
+// OrderTTLDuration 解析订单有效期
+func (c *Config) OrderTTLDuration() (time.Duration, error) {
+	if c.OrderTTL == "" {
+		return store.DefaultOrderTTL, nil
+	}
+	ttl, err := time.ParseDuration(c.OrderTTL)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("orderTtl 无效: %q", c.OrderTTL)
+	}
+	return ttl, nil
+}
+
+// OrderRetentionDuration 解析终态订单保留时间
+func (c *Config) OrderRetentionDuration() (time.Duration, error) {
+	if c.OrderRetention == "" {
+		return store.DefaultOrderRetention, nil
+	}
+	retention, err := time.ParseDuration(c.OrderRetention)
+	if err != nil || retention < 0 {
+		return 0, fmt.Errorf("orderRetention 无效: %q", c.OrderRetention)
+	}
+	return retention, nil
+}
+
+// ShutdownTimeoutDuration 解析优雅退出等待时间
+func (c *Config) ShutdownTimeoutDuration() (time.Duration, error) {
+	if c.ShutdownTimeout == "" {
+		return DefaultShutdownTimeout, nil
+	}
+	d, err := time.ParseDuration(c.ShutdownTimeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("shutdownTimeout 无效: %q", c.ShutdownTimeout)
+	}
+	return d, nil
+}
+
+// mergeProfiles 将 extra 合并到 base，同名 profile 以 extra 为准
+func mergeProfiles(base, extra []models.ParameterProfile) []models.ParameterProfile {
+	merged := append([]models.ParameterProfile(nil), base...)
+	for _, p := range extra {
+		replaced := false
+		for i := range merged {
+			if merged[i].Name == p.Name {
+				merged[i] = p
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, p)
+		}
+	}
+	return merged
+}
+
+// ValidateWebhooks 校验订阅 URL、密钥与事件类型
+func ValidateWebhooks(subscriptions []models.WebhookSubscription) error {
+	for i, s := range subscriptions {
+		u, err := url.Parse(s.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhook #%d URL 无效: %q", i+1, s.URL)
+		}
+		if s.Secret == "" {
+			return fmt.Errorf("webhook #%d 缺少 secret", i+1)
+		}
+		for _, t := range s.Events {
+			if !knownEvent(t) {
+				return fmt.Errorf("webhook #%d 未知的事件类型: %s", i+1, t)
+			}
+		}
+	}
+	return nil
+}
+
+// knownEvent 是否为已知事件类型
+func knownEvent(t models.EventType) bool {
+	for _, known := range models.EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateStrategies 校验策略名称非空且不重复，Omit 字段名有效
+func ValidateStrategies(strategies []models.Strategy) error {
+	seen := make(map[string]bool, len(strategies))
+	for i, s := range strategies {
+		if s.Name == "" {
+			return fmt.Errorf("策略 #%d 缺少 name", i+1)
+		}
+		if seen[s.Name] {
+			return fmt.Errorf("策略名称重复: %s", s.Name)
+		}
+		if err := s.Validate(); err != nil {
+			return err
+		}
+		seen[s.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/config"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+func TestAmountPolicyMerge(t *testing.T) {
+	dir := t.TempDir()
+	load := func(body string) *models.AmountPolicy {
+		t.Helper()
+		path := filepath.Join(dir, "config.json")
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := config.Load(path)
+		if err != nil {
+			t.Fatalf("加载配置失败: %v", err)
+		}
+		return cfg.AmountPolicy
+	}
+
+	defaults := models.DefaultAmountPolicy().Default
+	// 只配置 merchants 时保留默认上下限
+	p := load(`{"amountPolicy": {"merchants": {"M-1": {"max": "100.00"}}}}`)
+	if p.Default != defaults || p.Merchants["M-1"].Max != 100_00 {
+		t.Errorf("省略 default 时应沿用默认值: %+v", p)
+	}
+	// 只覆盖 max 时 min 仍为默认值
+	p = load(`{"amountPolicy": {"default": {"max": "1000.00"}}}`)
+	if p.Default.Min != defaults.Min || p.Default.Max != 1000_00 {
+		t.Errorf("default.min 应沿用默认值: %+v", p.Default)
+	}
+	// 显式写 0 取消限制
+	p = load(`{"amountPolicy": {"default": {"min": 0, "max": 0}}}`)
+	if p.Default != (models.AmountLimits{}) {
+		t.Errorf("显式 0 应取消限制: %+v", p.Default)
+	}
+}
+
+func TestValidateStrategies(t *testing.T) {
+	if err := config.ValidateStrategies([]models.Strategy{{Name: "bad", Omit: []string{"qrCode"}}}); err == nil {
+		t.Error("不能省略 qrCode")
+	}
+}
@@ -152,6 +152,9 @@ func (d *DeepLinkDebugger) DebugQRCode(qrCode string) {
 	validation := p.Validate(qrCode)
 	if validation.Valid {
 		fmt.Println("✅ QR Code 格式有效")
+		for _, warning := range validation.Warnings {
+			fmt.Printf("⚠️  %s\n", warning)
+		}
 	} else {
 		fmt.Println("❌ QR Code 格式无效：")
 		for _, err := range validation.Errors {
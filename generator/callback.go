@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// StatusPlaceholder 跳转 URL 中的支付状态占位符，由 /return/{orderId} 在用户返回时替换
+const StatusPlaceholder = "{status}"
+
+// SetURLPolicy 替换 redirectUrl / notifyUrl 校验策略，需在开始生成前调用
+func (g *DeepLinkGenerator) SetURLPolicy(policy models.URLPolicy) {
+	g.urlPolicy = policy
+}
+
+// SetReturnURL 设置本服务对外地址（如 https://pay.example.com）
+// redirectUrl 含 {status} 时，链接中的 redirectUrl 改为 {returnURL}/return/{orderId}
+func (g *DeepLinkGenerator) SetReturnURL(base string) {
+	g.returnURL = strings.TrimSuffix(base, "/")
+}
+
+// resolveURLs 展开 redirectUrl / notifyUrl 中的模板变量并按策略校验
+// 支持 {orderId} {amount} {merchantId} {paymentType}；redirectUrl 另支持 {status}
+func (g *DeepLinkGenerator) resolveURLs(r *models.ResolvedOptions) error {
+	if r.NotifyURL != "" {
+		if strings.Contains(r.NotifyURL, StatusPlaceholder) {
+			return models.ErrInvalidURL.Errorf(nil, "notifyUrl 不支持 %s", StatusPlaceholder)
+		}
+		expanded, err := expandURL(r.NotifyURL, r)
+		if err != nil {
+			return err
+		}
+		if err := g.urlPolicy.Check(expanded, r.MerchantID); err != nil {
+			return fmt.Errorf("notifyUrl: %w", err)
+		}
+		r.NotifyURL = expanded
+	}
+
+	if r.RedirectURL != "" {
+		expanded, err := expandURL(r.RedirectURL, r)
+		if err != nil {
+			return err
+		}
+		// {status} 以任意合法值代入校验
+		if err := g.urlPolicy.Check(strings.ReplaceAll(expanded, StatusPlaceholder, string(models.OrderPaid)), r.MerchantID); err != nil {
+			return fmt.Errorf("redirectUrl: %w", err)
+		}
+		r.RedirectURL = expanded
+
+		if strings.Contains(expanded, StatusPlaceholder) {
+			if g.returnURL == "" || r.OrderID == "" {
+				return models.ErrInvalidURL.Errorf(nil, "redirectUrl 含 %s 时需要 orderId 并配置 publicBaseUrl", StatusPlaceholder)
+			}
+			r.MerchantRedirectURL = expanded
+			r.RedirectURL = g.returnURL + "/return/" + url.PathEscape(r.OrderID)
+		}
+	}
+	return nil
+}
+
+// expandURL 替换模板变量（值按查询参数转义）；{status} 保留，未知变量视为格式错误
+func expandURL(template string, r *models.ResolvedOptions) (string, error) {
+	values := map[string]string{
+		"orderId":     r.OrderID,
+		"amount":      r.OrderAmount,
+		"merchantId":  r.MerchantID,
+		"paymentType": string(r.PaymentType),
+	}
+
+	var err error
+	expanded := placeholderPattern.ReplaceAllStringFunc(template, func(m string) string {
+		name := m[1 : len(m)-1]
+		if m == StatusPlaceholder {
+			return m
+		}
+		value, ok := values[name]
+		if !ok {
+			err = models.ErrInvalidURL.Errorf(nil, "未知的模板变量 %s", m)
+			return m
+		}
+		return url.QueryEscape(value)
+	})
+	return expanded, err
+}
+
+// ExpandStatus 替换跳转模板中的 {status}
+func ExpandStatus(template string, status models.OrderStatus) string {
+	return strings.ReplaceAll(template, StatusPlaceholder, url.QueryEscape(string(status)))
+}
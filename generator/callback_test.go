@@ -0,0 +1,71 @@
+package generator_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/generator"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	"github.com/qinyuanmao/gcash-deeplink/parser"
+)
+
+func TestCallbackURLs(t *testing.T) {
+	data, err := parser.NewEMVCoParser().Parse(p2mQR)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+
+	g := generator.NewDeepLinkGenerator()
+	g.SetURLPolicy(models.URLPolicy{
+		Hosts:     []string{"*.myshop.com"},
+		Merchants: map[string][]string{"M-2": {"other.example"}},
+	})
+
+	// 模板变量展开
+	result, err := g.Generate(data, &models.DeepLinkOptions{
+		OrderID:     "ORDER 1",
+		RedirectURL: "https://pay.myshop.com/done?order={orderId}&amount={amount}",
+		NotifyURL:   "https://api.myshop.com/hooks/{orderId}",
+	})
+	if err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	if result.Resolved.RedirectURL != "https://pay.myshop.com/done?order=ORDER+1&amount=100.00" {
+		t.Errorf("redirectUrl 展开错误: %s", result.Resolved.RedirectURL)
+	}
+	if !containsParam(result.DeepLink, "notifyUrl", "https://api.myshop.com/hooks/ORDER+1") {
+		t.Errorf("notifyUrl 展开错误: %s", result.DeepLink)
+	}
+
+	rejected := []models.DeepLinkOptions{
+		{RedirectURL: "https://evil.example/phish"},                    // 不在允许列表
+		{RedirectURL: "http://pay.myshop.com/done"},                    // 非 https
+		{RedirectURL: "/relative/path"},                                // 非绝对地址
+		{RedirectURL: "https://user:pw@pay.myshop.com/"},               // 用户信息
+		{NotifyURL: "https://api.myshop.com/{foo}"},                    // 未知模板变量
+		{NotifyURL: "https://api.myshop.com/{status}"},                 // notifyUrl 不支持 {status}
+		{RedirectURL: "https://pay.myshop.com/{status}", OrderID: "X"}, // 未配置 publicBaseUrl
+		{RedirectURL: "https://pay.myshop.com/", MerchantID: "M-2"},    // 商户允许列表优先
+	}
+	for _, options := range rejected {
+		options := options
+		if _, err := g.Generate(data, &options); !errors.Is(err, models.ErrInvalidURL) && !errors.Is(err, models.ErrURLNotAllowed) {
+			t.Errorf("%+v 应被拒绝, got %v", options, err)
+		}
+	}
+
+	// 未配置允许列表时拒绝任何回调 URL
+	if _, err := generator.NewDeepLinkGenerator().Generate(data, &models.DeepLinkOptions{RedirectURL: "https://pay.myshop.com/done"}); !errors.Is(err, models.ErrURLNotAllowed) {
+		t.Errorf("未配置允许列表应拒绝回调 URL, got %v", err)
+	}
+
+	// {status}: 链接跳转到本服务 /return/{orderId}，返回时按订单状态展开
+	g.SetReturnURL("https://gateway.example/")
+	result, err = g.Generate(data, &models.DeepLinkOptions{OrderID: "ORDER-RET", RedirectURL: "https://pay.myshop.com/result?status={status}"})
+	if err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	if !containsParam(result.DeepLink, "redirectUrl", "https://gateway.example/return/ORDER-RET") {
+		t.Errorf("redirectUrl 应指向 /return: %s", result.DeepLink)
+	}
+}
@@ -0,0 +1,178 @@
+package generator
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	"github.com/qinyuanmao/gcash-deeplink/parser"
+)
+
+// DecodeDeepLink 解码 Deep Link：按顺序列出查询参数，用已配置的参数布局还原模板字段，并解析其中的 qrCode
+// 只有链接本身无效时返回错误；qrCode 缺失或解析失败记录在结果的 Error / ErrorCode 中
+func (g *DeepLinkGenerator) DecodeDeepLink(link string) (*models.DecodedDeepLink, error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return nil, models.ErrInvalidDeepLink.Errorf(nil, "%w", err)
+	}
+	if u.Scheme == "" || u.Opaque != "" {
+		return nil, models.ErrInvalidDeepLink.Errorf(nil, "缺少 scheme 或路径")
+	}
+	params, err := queryParams(u.RawQuery)
+	if err != nil {
+		return nil, models.ErrInvalidDeepLink.Errorf(nil, "%w", err)
+	}
+
+	base := *u
+	base.RawQuery, base.Fragment = "", ""
+	decoded := &models.DecodedDeepLink{BaseURL: base.String(), Params: params}
+	decoded.Profile, decoded.Fields = g.matchProfile(decoded.BaseURL, params)
+
+	qrCode := decoded.Fields["qrCode"]
+	if decoded.Profile == "" {
+		qrCode = paramValue(params, "qrCode")
+	}
+	if qrCode == "" {
+		decoded.Error, decoded.ErrorCode = models.ErrQREmpty.Error(), models.CodeQREmpty
+		return decoded, nil
+	}
+	p := parser.NewEMVCoParser()
+	p.SetMetrics(g.metrics)
+	if decoded.ParsedData, err = p.Parse(qrCode); err != nil {
+		decoded.Error, decoded.ErrorCode = err.Error(), models.CodeOf(err)
+	}
+	return decoded, nil
+}
+
+// queryParams 按原顺序解码查询参数（url.ParseQuery 返回的 map 不保留顺序）
+func queryParams(rawQuery string) ([]models.DeepLinkParam, error) {
+	var params []models.DeepLinkParam
+	for _, pair := range strings.Split(rawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(k)
+		if err != nil {
+			return nil, err
+		}
+		value, err := url.QueryUnescape(v)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, models.DeepLinkParam{Key: key, Value: value})
+	}
+	return params, nil
+}
+
+// paramValue 第一个同名参数的值
+func paramValue(params []models.DeepLinkParam, key string) string {
+	for _, p := range params {
+		if p.Key == key {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+// matchProfile 找出能还原全部参数的参数布局，多个布局匹配时取还原字段最多的（同数量按名称排序）
+func (g *DeepLinkGenerator) matchProfile(baseURL string, params []models.DeepLinkParam) (string, map[string]string) {
+	names := make([]string, 0, len(g.profiles))
+	for name := range g.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var bestName string
+	var best map[string]string
+	for _, name := range names {
+		fields, ok := decodeProfile(g.profiles[name], baseURL, params)
+		if ok && (best == nil || len(fields) > len(best)) {
+			bestName, best = name, fields
+		}
+	}
+	return bestName, best
+}
+
+// decodeProfile 按参数布局反推模板字段
+// 链接中的参数必须都属于该布局，Always 参数必须存在，同一字段在多个参数中的取值必须一致
+func decodeProfile(profile models.ParameterProfile, baseURL string, params []models.DeepLinkParam) (map[string]string, bool) {
+	profileBase := profile.BaseURL
+	if profileBase == "" {
+		profileBase = GCashBaseURL
+	}
+	if baseURL != profileBase {
+		return nil, false
+	}
+
+	templates := make(map[string]models.ParameterField, len(profile.Params))
+	for _, field := range profile.Params {
+		if _, ok := templates[field.Key]; !ok {
+			templates[field.Key] = field
+		}
+	}
+	present := make(map[string]bool, len(params))
+	fields := map[string]string{}
+	for _, p := range params {
+		field, ok := templates[p.Key]
+		if !ok {
+			return nil, false
+		}
+		present[p.Key] = true
+		values, ok := matchTemplate(field.Value, p.Value)
+		if !ok {
+			return nil, false
+		}
+		for name, value := range values {
+			if value == "" {
+				continue
+			}
+			if prev, seen := fields[name]; seen && prev != value {
+				return nil, false
+			}
+			fields[name] = value
+		}
+	}
+	for _, field := range profile.Params {
+		if field.Always && len(field.Requires) == 0 && !present[field.Key] {
+			return nil, false
+		}
+	}
+	return fields, true
+}
+
+// matchTemplate 用参数模板匹配值，返回占位符对应的取值
+func matchTemplate(template, value string) (map[string]string, bool) {
+	locs := placeholderPattern.FindAllStringSubmatchIndex(template, -1)
+	if len(locs) == 0 {
+		return nil, template == value
+	}
+
+	var pattern strings.Builder
+	names := make([]string, 0, len(locs))
+	pattern.WriteString("^")
+	last := 0
+	for _, loc := range locs {
+		pattern.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		pattern.WriteString("(.*?)")
+		names = append(names, template[loc[2]:loc[3]])
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+	pattern.WriteString("$")
+
+	m := regexp.MustCompile(pattern.String()).FindStringSubmatch(value)
+	if m == nil {
+		return nil, false
+	}
+	values := make(map[string]string, len(names))
+	for i, name := range names {
+		if prev, seen := values[name]; seen && prev != m[i+1] {
+			return nil, false
+		}
+		values[name] = m[i+1]
+	}
+	return values, true
+}
@@ -16,41 +16,199 @@ const (
 )
 
 // DeepLinkGenerator GCash Deep Link 生成器
-type DeepLinkGenerator struct{}
+type DeepLinkGenerator struct {
+	strategies     []models.Strategy                  // GenerateMultiple 使用的策略集
+	profiles       map[string]models.ParameterProfile // 可用的参数布局
+	defaultProfile string                             // 未指定 profile 时使用的布局
+	amountPolicy   models.AmountPolicy                // 金额限制
+	mccPolicy      models.MCCPolicy                   // 商户分类规则
+	events         models.EventSink                   // 事件推送（可选）
+	urlPolicy      models.URLPolicy                   // 回调 URL 校验
+	returnURL      string                             // 本服务对外地址，用于 {status} 跳转
+	metrics        models.MetricsHook                 // 指标回调（可选）
+	audit          models.AuditSink                   // 审计记录（可选）
+	orders         models.OrderRegistry               // 订单登记（可选）
+}
 
 // NewDeepLinkGenerator 创建生成器实例
 func NewDeepLinkGenerator() *DeepLinkGenerator {
-	return &DeepLinkGenerator{}
+	g := &DeepLinkGenerator{
+		strategies:   DefaultStrategies(),
+		amountPolicy: models.DefaultAmountPolicy(),
+		mccPolicy:    models.DefaultMCCPolicy(),
+	}
+	_ = g.SetProfiles(DefaultProfiles(), DefaultProfileName)
+	return g
 }
 
 // Generate 生成 GCash Deep Link
+// data 与 options 均视为只读输入，可在多个 goroutine 间共享同一份解析结果；
+// 实际生效的参数通过 DeepLinkResult.Resolved 返回
 func (g *DeepLinkGenerator) Generate(data *models.EMVCoData, options *models.DeepLinkOptions) (*models.DeepLinkResult, error) {
+	return g.run(data, options, true)
+}
+
+// run 生成并上报指标；record 为 false 时只预览链接，不登记订单、不写审计记录、不发布事件
+func (g *DeepLinkGenerator) run(data *models.EMVCoData, options *models.DeepLinkOptions, record bool) (*models.DeepLinkResult, error) {
+	start := time.Now()
+	result, err := g.generate(data, options, record)
+	if g.metrics != nil {
+		g.metrics.ObserveGenerate(models.GenerateEvent{
+			Data:     data,
+			Resolved: result.Resolved,
+			DeepLink: result.DeepLink,
+			Err:      err,
+			Duration: time.Since(start),
+		})
+	}
+	return result, err
+}
+
+func (g *DeepLinkGenerator) generate(data *models.EMVCoData, options *models.DeepLinkOptions, record bool) (*models.DeepLinkResult, error) {
 	// 验证输入
 	if data == nil {
-		return g.errorResult("解析数据不能为空")
+		return g.errorResultFrom(models.ErrDataRequired)
+	}
+	if err := g.checkSettlement(data); err != nil {
+		return g.errorResultFrom(err)
+	}
+	var input models.DeepLinkOptions
+	if options != nil {
+		input = *options
 	}
-	if options == nil {
-		options = &models.DeepLinkOptions{}
+
+	// 确定 QR 布局（未指定时自动识别）
+	format, detection := g.resolveFormat(data, &input)
+	if format != models.QRFormatLegacy && format != models.QRFormatNew {
+		return g.errorResultFrom(models.ErrUnsupportedQRFormat.Errorf(models.Params{"format": string(format)}, "%s", format))
 	}
 
 	// 填充默认值
-	g.fillDefaults(data, options)
+	resolved := g.resolveOptions(data, input, format)
+
+	// 商户分类规则（如拒绝博彩类商户、部分类别必须提供订单号）
+	if err := g.mccPolicy.Check(resolved.MerchantCategoryCode, resolved.OrderID); err != nil {
+		return g.errorResultFrom(err)
+	}
+
+	// 金额规范化与校验
+	if err := g.normalizeAmount(data, input, resolved); err != nil {
+		return g.errorResultFrom(err)
+	}
+
+	// 回调 URL 模板展开与校验
+	if err := g.resolveURLs(resolved); err != nil {
+		return g.errorResultFrom(err)
+	}
+
+	// 参数布局
+	profile, ok := g.profiles[resolved.Profile]
+	if !ok {
+		return g.errorResultFrom(models.ErrUnknownProfile.Errorf(models.Params{"profile": resolved.Profile}, "%s", resolved.Profile))
+	}
 
 	// 构建参数
-	values := g.buildParameters(data, options)
+	values := g.buildParameters(profile, resolved)
 
 	// 生成 Deep Link
 	// 使用 %20 替换 + 编码空格，确保 Android Uri.getQueryParameter() 正确解码
 	query := strings.ReplaceAll(values.Encode(), "+", "%20")
-	deepLink := fmt.Sprintf("%s?%s", GCashBaseURL, query)
+	baseURL := profile.BaseURL
+	if baseURL == "" {
+		baseURL = GCashBaseURL
+	}
+	deepLink := fmt.Sprintf("%s?%s", baseURL, query)
+
+	result := &models.DeepLinkResult{
+		Success:         true,
+		DeepLink:        deepLink,
+		ParsedData:      data,
+		Resolved:        resolved,
+		GeneratedAt:     time.Now(),
+		QRFormat:        format,
+		FormatDetection: detection,
+	}
+	if options != nil {
+		result.Options = &input
+	}
 
-	return &models.DeepLinkResult{
-		Success:     true,
-		DeepLink:    deepLink,
-		ParsedData:  data,
-		Options:     options,
-		GeneratedAt: time.Now(),
-	}, nil
+	if !record {
+		return result, nil
+	}
+
+	// 订单冲突检查 → 审计 → 登记订单：冲突时不留审计记录，审计失败时不登记订单
+	appendAudit := func() error {
+		if g.audit == nil {
+			return nil
+		}
+		err := g.audit.Append(models.AuditRecord{
+			MerchantID:  resolved.MerchantID,
+			OrderID:     resolved.OrderID,
+			QRHash:      models.HashValue(data.RawData),
+			DeepLink:    deepLink,
+			Resolved:    resolved,
+			GeneratedAt: result.GeneratedAt,
+		})
+		if err != nil {
+			return models.ErrAuditFailed.Errorf(nil, "%w", err)
+		}
+		return nil
+	}
+	var err error
+	if g.orders != nil && resolved.OrderID != "" {
+		_, err = g.orders.SaveWith(result, appendAudit)
+	} else {
+		err = appendAudit()
+	}
+	if err != nil {
+		return g.errorResultFrom(err)
+	}
+	if g.events != nil {
+		g.events.Publish(models.NewEvent(models.EventLinkGenerated, resolved.MerchantID, resolved.OrderID,
+			models.LinkGeneratedData{DeepLink: deepLink, Resolved: resolved}))
+	}
+	return result, nil
+}
+
+// checkSettlement 拒绝 GCash 无法结算的 QR Code（非 QR Ph 网络、非 PHP、非菲律宾）
+// 以及 P2P 转账 QR（尚无经过验证的 GCash P2P 链接格式）
+// 字段为空时不拦截，兼容调用方自行构造的 EMVCoData
+func (g *DeepLinkGenerator) checkSettlement(data *models.EMVCoData) error {
+	if data.QRType == models.QRTypeP2P {
+		return models.ErrP2PUnsupported
+	}
+	if data.Network != "" && data.Network != models.NetworkQRPh {
+		return models.ErrUnsupportedNetwork.Errorf(models.Params{"network": string(data.Network)}, "QR Code 属于 %s 网络", data.Network)
+	}
+	if data.Currency != "" && data.Currency != models.CurrencyPHP {
+		name := data.Currency
+		if c, ok := models.LookupCurrency(data.Currency); ok {
+			name = c.String()
+		}
+		return models.ErrUnsupportedCurrency.Errorf(models.Params{"currency": name}, "QR Code 货币为 %s，仅支持 PHP (608)", name)
+	}
+	if data.CountryCode != "" && !strings.EqualFold(data.CountryCode, models.CountryPH) {
+		return models.ErrUnsupportedCountry.Errorf(models.Params{"country": data.CountryCode}, "QR Code 国家为 %s，仅支持 PH", data.CountryCode)
+	}
+	return nil
+}
+
+// resolveFormat 确定实际采用的 QR 布局
+// 调用方显式指定时直接使用；否则使用解析器的识别结果，detection 仅在自动识别时返回
+func (g *DeepLinkGenerator) resolveFormat(data *models.EMVCoData, options *models.DeepLinkOptions) (models.QRFormat, *models.QRFormatDetection) {
+	if options.NewQRFormat {
+		return models.QRFormatNew, nil
+	}
+	if options.QRFormat != models.QRFormatAuto {
+		return options.QRFormat, nil
+	}
+
+	detection := data.FormatDetection
+	if detection == nil {
+		// 调用方自行构造的 EMVCoData 没有经过 Parse
+		detection = parser.NewEMVCoParser().DetectFormat(data)
+	}
+	return detection.Format, detection
 }
 
 // GenerateWithValidation 解析 QR Code 并生成 Deep Link
@@ -58,15 +216,89 @@ func (g *DeepLinkGenerator) Generate(data *models.EMVCoData, options *models.Dee
 // GCash 后端会自行校验 QR 码，因此此处不再额外调用 Validate() 拦截
 func (g *DeepLinkGenerator) GenerateWithValidation(qrData string, options *models.DeepLinkOptions) (*models.DeepLinkResult, error) {
 	p := parser.NewEMVCoParser()
+	p.SetMetrics(g.metrics)
 	data, err := p.Parse(qrData)
 	if err != nil {
-		return g.errorResult(fmt.Sprintf("解析失败: %v", err))
+		return g.errorResultFrom(fmt.Errorf("解析失败: %w", err))
 	}
 	return g.Generate(data, options)
 }
 
-// fillDefaults 填充默认值
-func (g *DeepLinkGenerator) fillDefaults(data *models.EMVCoData, options *models.DeepLinkOptions) {
+// SetAmountPolicy 替换金额限制策略，需在开始生成前调用
+func (g *DeepLinkGenerator) SetAmountPolicy(policy models.AmountPolicy) {
+	g.amountPolicy = policy
+}
+
+// SetEventSink 设置事件推送，成功生成链接时发布 link.generated
+func (g *DeepLinkGenerator) SetEventSink(sink models.EventSink) {
+	g.events = sink
+}
+
+// SetMetrics 设置指标回调，每次 Generate 完成时调用；GenerateWithValidation 的解析也会上报
+func (g *DeepLinkGenerator) SetMetrics(hook models.MetricsHook) {
+	g.metrics = hook
+}
+
+// SetAuditSink 设置审计记录，每次成功生成时写入；写入失败则生成失败
+func (g *DeepLinkGenerator) SetAuditSink(sink models.AuditSink) {
+	g.audit = sink
+}
+
+// SetOrderRegistry 设置订单登记，带 orderId 的链接在冲突检查通过、审计记录写入成功后登记，再发布事件
+func (g *DeepLinkGenerator) SetOrderRegistry(orders models.OrderRegistry) {
+	g.orders = orders
+}
+
+// SetMCCPolicy 替换商户分类规则，需在开始生成前调用
+func (g *DeepLinkGenerator) SetMCCPolicy(policy models.MCCPolicy) {
+	g.mccPolicy = policy
+}
+
+// normalizeAmount 规范化并校验订单金额（格式化为两位小数）
+// 金额为空时不校验（静态 QR 由用户在 GCash 内输入金额）
+func (g *DeepLinkGenerator) normalizeAmount(data *models.EMVCoData, input models.DeepLinkOptions, resolved *models.ResolvedOptions) error {
+	if resolved.OrderAmount == "" {
+		return nil
+	}
+	amount, err := models.ParseAmount(resolved.OrderAmount)
+	if err != nil {
+		return err
+	}
+
+	// 动态 QR 的 Tag 54 为固定金额，调用方指定的金额必须一致
+	if input.OrderAmount != "" && data.InitMethod == models.InitMethodDynamic && data.Amount != "" {
+		if fixed, err := models.ParseAmount(data.Amount); err == nil && fixed != amount {
+			return models.ErrAmountMismatch.Errorf(models.Params{"amount": amount.String(), "qrAmount": fixed.String()}, "请求金额 %s, QR Code 金额 %s", amount, fixed)
+		}
+	}
+
+	if err := g.amountPolicy.LimitsFor(resolved.MerchantID, resolved.PaymentType).Check(amount); err != nil {
+		return err
+	}
+	resolved.OrderAmount = amount.String()
+	return nil
+}
+
+// resolveOptions 在调用方选项的副本上填充默认值，不修改 data 与调用方 options
+func (g *DeepLinkGenerator) resolveOptions(data *models.EMVCoData, input models.DeepLinkOptions, format models.QRFormat) *models.ResolvedOptions {
+	r := &models.ResolvedOptions{
+		DeepLinkOptions:      input,
+		QRType:               data.QRType,
+		AccountNumber:        data.AccountNumber,
+		AccountName:          data.AccountName,
+		BankCode:             data.BankCode,
+		BillNumber:           data.OrderID,
+		AcqInfo:              data.AcqInfo,
+		TerminalLabel:        data.TerminalLabel,
+		MerchantCity:         data.MerchantCity,
+		MerchantCategoryCode: data.MerchantCategoryCode,
+	}
+	options := &r.DeepLinkOptions
+
+	// QR 布局
+	options.QRFormat = format
+	options.NewQRFormat = format == models.QRFormatNew
+
 	// QR Code 数据
 	if options.QRCode == "" {
 		options.QRCode = data.RawData
@@ -99,12 +331,13 @@ func (g *DeepLinkGenerator) fillDefaults(data *models.EMVCoData, options *models
 	// 新版 QR 格式: 28-03=UID, 62-05=订单号
 	// 交换 shopId 和 acqInfo，使 shopId=订单号, acqInfo=UID
 	// 仅在两个值都非空时才交换，防止 ShopID 被清空导致 param5 丢失
-	if options.NewQRFormat && data.AcqInfo != "" && data.ShopID != "" {
-		data.ShopID, data.AcqInfo = data.AcqInfo, data.ShopID
+	shopID := data.ShopID
+	if format == models.QRFormatNew && data.AcqInfo != "" && data.ShopID != "" {
+		shopID, r.AcqInfo = data.AcqInfo, data.ShopID
 	}
 
 	if options.ShopID == "" {
-		options.ShopID = data.ShopID
+		options.ShopID = shopID
 	}
 
 	if options.MerchantName == "" {
@@ -115,47 +348,13 @@ func (g *DeepLinkGenerator) fillDefaults(data *models.EMVCoData, options *models
 	if options.BizNo == "" {
 		options.BizNo = "null"
 	}
-}
-
-// buildParameters 构建 URL 参数
-func (g *DeepLinkGenerator) buildParameters(data *models.EMVCoData, options *models.DeepLinkOptions) url.Values {
-	values := url.Values{}
 
-	// 必需参数
-	values.Add("qrCode", options.QRCode)
-	values.Add("bizNo", options.BizNo)
-	values.Add("orderAmount", options.OrderAmount)
-	values.Add("qrCodeFormat", "EMVCO")
-	values.Add("sub", "p2mpay")
-	values.Add("clientId", options.ClientID)
-	values.Add("merchantName", options.MerchantName)
-
-	// 可选参数 - 只在有值时添加
-	g.addIfNotEmpty(values, "merchantId", options.MerchantID)
-	g.addIfNotEmpty(values, "orderId", options.OrderID)
-	g.addIfNotEmpty(values, "tfrbnkcode", data.BankCode)
-	g.addIfNotEmpty(values, "shopId", options.ShopID)
-	g.addIfNotEmpty(values, "tfrAcctNo", options.ShopID)
-	g.addIfNotEmpty(values, "acqInfo", data.AcqInfo)
-
-	// 回调 URL
-	g.addIfNotEmpty(values, "redirectUrl", options.RedirectURL)
-	g.addIfNotEmpty(values, "returnUrl", options.RedirectURL)
-	g.addIfNotEmpty(values, "notifyUrl", options.NotifyURL)
-	g.addIfNotEmpty(values, "callbackUrl", options.NotifyURL)
-
-	// param3 和 param5
-	param3 := g.buildParam3(options)
-	param5 := g.buildParam5(data, options)
-	g.addIfNotEmpty(values, "param3", param3)
-	g.addIfNotEmpty(values, "param5", param5)
-
-	// GCash PAY_QR 需要的额外参数
-	g.addIfNotEmpty(values, "merchantCity", data.MerchantCity)
-	g.addIfNotEmpty(values, "merchantCategoryCode", data.MerchantCategoryCode)
-	values.Add("lucky", "false")
+	// 参数布局
+	if options.Profile == "" {
+		options.Profile = g.defaultProfile
+	}
 
-	return values
+	return r
 }
 
 // addIfNotEmpty 只在值非空时添加参数
@@ -165,60 +364,117 @@ func (g *DeepLinkGenerator) addIfNotEmpty(values url.Values, key, value string)
 	}
 }
 
-// buildParam3 构建 param3 参数
-func (g *DeepLinkGenerator) buildParam3(options *models.DeepLinkOptions) string {
-	return fmt.Sprintf("99960005~ph.ppmi.p2m~~~%s", options.PaymentType)
+// errorResultFrom 由 error 创建错误结果，保留错误链供 errors.Is 判断
+func (g *DeepLinkGenerator) errorResultFrom(err error) (*models.DeepLinkResult, error) {
+	return &models.DeepLinkResult{
+		Success:     false,
+		Error:       err.Error(),
+		ErrorCode:   models.CodeOf(err),
+		GeneratedAt: time.Now(),
+	}, err
 }
 
-// buildParam5 构建 param5 参数
-// 格式: ShopID~MerchantName~TerminalLabel~AcqInfo (4段3波浪，对齐 Luca 模板)
-func (g *DeepLinkGenerator) buildParam5(data *models.EMVCoData, options *models.DeepLinkOptions) string {
-	if options.ShopID == "" {
-		return ""
+// DefaultStrategies 内置策略集（未配置策略时使用）
+// 策略覆盖支付类型，并通过 Omit 决定是否携带请求中的订单号与回调 URL
+func DefaultStrategies() []models.Strategy {
+	return []models.Strategy{
+		{
+			Name:        "minimal",
+			Description: "最简化: 标准 P2M 支付，只保留 QR Code 与金额",
+			Options:     models.DeepLinkOptions{PaymentType: models.PaymentTypeStandard},
+			Omit:        []string{"merchantName", "orderId", "redirectUrl", "notifyUrl", "clientId", "shopId", "bizNo"},
+		},
+		{
+			Name:        "dynamic",
+			Description: "动态 QR 支付，不携带回调 URL",
+			Options:     models.DeepLinkOptions{PaymentType: models.PaymentTypeDynamic},
+			Omit:        []string{"redirectUrl", "notifyUrl"},
+		},
+		{
+			Name:        "with_callback",
+			Description: "动态 QR 支付，携带请求中的 redirectUrl / notifyUrl",
+			Options:     models.DeepLinkOptions{PaymentType: models.PaymentTypeDynamic},
+		},
 	}
-	return fmt.Sprintf("%s~%s~%s~%s", options.ShopID, options.MerchantName, data.TerminalLabel, data.AcqInfo)
 }
 
-// errorResult 创建错误结果
-func (g *DeepLinkGenerator) errorResult(errMsg string) (*models.DeepLinkResult, error) {
-	return &models.DeepLinkResult{
-		Success:     false,
-		Error:       errMsg,
-		GeneratedAt: time.Now(),
-	}, fmt.Errorf(errMsg)
+// SetStrategies 替换 GenerateMultiple 使用的策略集，需在开始生成前调用
+func (g *DeepLinkGenerator) SetStrategies(strategies []models.Strategy) {
+	g.strategies = strategies
 }
 
-// GenerateMultiple 生成多种策略的 Deep Link
-func (g *DeepLinkGenerator) GenerateMultiple(data *models.EMVCoData) map[string]string {
-	strategies := make(map[string]string)
+// Strategies 返回当前策略集
+func (g *DeepLinkGenerator) Strategies() []models.Strategy {
+	return g.strategies
+}
 
-	// 策略 1: 最简化
-	result1, _ := g.Generate(data, &models.DeepLinkOptions{
-		PaymentType: models.PaymentTypeStandard,
-	})
-	if result1.Success {
-		strategies["minimal"] = result1.DeepLink
+// GenerateStrategies 按顺序对每个策略生成 Deep Link
+// 每个策略的 Options 叠加在去掉 Omit 字段的 base 之上；strategies 为空时使用生成器的策略集
+// 结果仅用于对比预览：不登记订单、不写审计记录、不发布事件
+func (g *DeepLinkGenerator) GenerateStrategies(data *models.EMVCoData, base *models.DeepLinkOptions, strategies []models.Strategy) []models.StrategyResult {
+	if len(strategies) == 0 {
+		strategies = g.strategies
+	}
+	var baseOptions models.DeepLinkOptions
+	if base != nil {
+		baseOptions = *base
 	}
 
-	// 策略 2: 动态支付
-	result2, _ := g.Generate(data, &models.DeepLinkOptions{
-		PaymentType: models.PaymentTypeDynamic,
-		OrderID:     fmt.Sprintf("ORDER-%d", time.Now().Unix()),
-	})
-	if result2.Success {
-		strategies["dynamic"] = result2.DeepLink
+	results := make([]models.StrategyResult, 0, len(strategies))
+	for _, s := range strategies {
+		result, _ := g.generateStrategy(data, baseOptions, s)
+		results = append(results, models.StrategyResult{Name: s.Name, DeepLinkResult: *result})
 	}
+	return results
+}
 
-	// 策略 3: 带回调
-	result3, _ := g.Generate(data, &models.DeepLinkOptions{
-		PaymentType: models.PaymentTypeDynamic,
-		OrderID:     fmt.Sprintf("ORDER-%d", time.Now().Unix()),
-		RedirectURL: "https://yourdomain.com/payment/success",
-		NotifyURL:   "https://yourdomain.com/api/gcash/notify",
-	})
-	if result3.Success {
-		strategies["with_callback"] = result3.DeepLink
+// GenerateMultiple 按生成器的策略集生成 Deep Link，返回策略名 → 链接
+// 失败的策略不出现在 links 中，其错误（*models.Error，带错误码）按策略名放入 errs；全部成功时 errs 为空
+func (g *DeepLinkGenerator) GenerateMultiple(data *models.EMVCoData) (links map[string]string, errs map[string]error) {
+	links = make(map[string]string)
+	errs = make(map[string]error)
+	for _, s := range g.strategies {
+		result, err := g.generateStrategy(data, models.DeepLinkOptions{}, s)
+		if err != nil {
+			errs[s.Name] = err
+			continue
+		}
+		links[s.Name] = result.DeepLink
 	}
+	return links, errs
+}
 
-	return strategies
+// generateStrategy 以 base 去掉 Omit 字段后叠加策略选项生成预览（不登记订单、不写审计记录、不发布事件）
+func (g *DeepLinkGenerator) generateStrategy(data *models.EMVCoData, base models.DeepLinkOptions, s models.Strategy) (*models.DeepLinkResult, error) {
+	options := overlayOptions(base.Without(s.Omit), s.Options)
+	return g.run(data, &options, false)
+}
+
+// overlayOptions 将 over 中的非零值字段覆盖到 base 上
+func overlayOptions(base, over models.DeepLinkOptions) models.DeepLinkOptions {
+	return models.DeepLinkOptions{
+		QRCode:       pick(base.QRCode, over.QRCode),
+		OrderAmount:  pick(base.OrderAmount, over.OrderAmount),
+		MerchantID:   pick(base.MerchantID, over.MerchantID),
+		MerchantName: pick(base.MerchantName, over.MerchantName),
+		OrderID:      pick(base.OrderID, over.OrderID),
+		PaymentType:  pick(base.PaymentType, over.PaymentType),
+		RedirectURL:  pick(base.RedirectURL, over.RedirectURL),
+		NotifyURL:    pick(base.NotifyURL, over.NotifyURL),
+		ClientID:     pick(base.ClientID, over.ClientID),
+		ShopID:       pick(base.ShopID, over.ShopID),
+		BizNo:        pick(base.BizNo, over.BizNo),
+		QRFormat:     pick(base.QRFormat, over.QRFormat),
+		NewQRFormat:  pick(base.NewQRFormat, over.NewQRFormat),
+		Profile:      pick(base.Profile, over.Profile),
+	}
+}
+
+// pick over 非零值时取 over，否则取 base
+func pick[T comparable](base, over T) T {
+	var zero T
+	if over != zero {
+		return over
+	}
+	return base
 }
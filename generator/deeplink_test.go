@@ -0,0 +1,384 @@
+package generator_test
+
+import (
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/generator"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	"github.com/qinyuanmao/gcash-deeplink/parser"
+	"github.com/qinyuanmao/gcash-deeplink/store"
+)
+
+const p2mQR = "00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275"
+
+// recordingSink 记录发布的事件
+type recordingSink struct {
+	events []models.Event
+}
+
+func (s *recordingSink) Publish(e models.Event) {
+	s.events = append(s.events, e)
+}
+
+func TestAuditFailureDoesNotRegisterOrder(t *testing.T) {
+	orders := store.NewOrderStore()
+	events := &recordingSink{}
+	g := generator.NewDeepLinkGenerator()
+	g.SetOrderRegistry(orders)
+	g.SetEventSink(events)
+	g.SetAuditSink(models.AuditSinkFunc(func(models.AuditRecord) error { return errors.New("磁盘已满") }))
+
+	if _, err := g.GenerateWithValidation(p2mQR, &models.DeepLinkOptions{OrderID: "ORDER-A1"}); !errors.Is(err, models.ErrAuditFailed) {
+		t.Fatalf("审计写入失败应返回 ErrAuditFailed, got %v", err)
+	}
+	if _, err := orders.Get("ORDER-A1"); !errors.Is(err, models.ErrOrderNotFound) {
+		t.Errorf("审计失败时不应登记订单, got %v", err)
+	}
+	if len(events.events) != 0 {
+		t.Errorf("审计失败时不应发布事件, got %d", len(events.events))
+	}
+
+	// 审计恢复后可以正常签发
+	audited := 0
+	g.SetAuditSink(models.AuditSinkFunc(func(models.AuditRecord) error { audited++; return nil }))
+	if _, err := g.GenerateWithValidation(p2mQR, &models.DeepLinkOptions{OrderID: "ORDER-A1"}); err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	if _, err := orders.Get("ORDER-A1"); err != nil || audited != 1 {
+		t.Errorf("应登记订单并写入审计记录: audited=%d err=%v", audited, err)
+	}
+}
+
+func TestGenerateMultipleErrors(t *testing.T) {
+	data, err := parser.NewEMVCoParser().Parse(p2mQR)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	g := generator.NewDeepLinkGenerator()
+	g.SetStrategies([]models.Strategy{
+		{Name: "minimal", Options: models.DeepLinkOptions{PaymentType: models.PaymentTypeStandard}},
+		{Name: "bad_amount", Options: models.DeepLinkOptions{OrderAmount: "1,000"}},
+	})
+
+	links, errs := g.GenerateMultiple(data)
+	if links["minimal"] == "" || len(links) != 1 {
+		t.Errorf("成功的策略应返回链接: %v", links)
+	}
+	if len(errs) != 1 || !errors.Is(errs["bad_amount"], models.ErrAmountFormat) {
+		t.Errorf("失败的策略应按策略名返回错误: %v", errs)
+	}
+}
+
+func TestGenerateDoesNotMutateInputs(t *testing.T) {
+	qrCode := "00020101021228600011ph.ppmi.p2m0111DCPHPHM1XXX031920828990834787223040503011520448165303608540410005802PH5909BuzhuYazi6011Baguio city62380011ph.ppmi.p2m051921653329512971919506304EC47"
+
+	p := parser.NewEMVCoParser()
+	data, err := p.Parse(qrCode)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	before := *data
+
+	g := generator.NewDeepLinkGenerator()
+	options := &models.DeepLinkOptions{OrderID: "ORDER-1"}
+	first, err := g.Generate(data, options)
+	if err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+
+	if *options != (models.DeepLinkOptions{OrderID: "ORDER-1"}) {
+		t.Errorf("调用方 options 被修改: %+v", *options)
+	}
+	if data.ShopID != before.ShopID || data.AcqInfo != before.AcqInfo {
+		t.Errorf("解析数据被修改: shopId=%s, acqInfo=%s", data.ShopID, data.AcqInfo)
+	}
+	if first.Resolved.PaymentType != models.PaymentTypeDynamic {
+		t.Errorf("resolved paymentType 错误: got %s", first.Resolved.PaymentType)
+	}
+
+	// 复用同一份解析数据并发生成，结果应与首次一致
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := g.Generate(data, options)
+			if err != nil {
+				t.Errorf("生成失败: %v", err)
+				return
+			}
+			if result.DeepLink != first.DeepLink {
+				t.Errorf("重复生成结果不一致:\n%s\n%s", result.DeepLink, first.DeepLink)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestGenerateAutoDetectsNewQRFormat(t *testing.T) {
+	// 未指定格式时，新版 QR 应自动按新格式交换 shopId 与 acqInfo
+	qrCode := "00020101021228600011ph.ppmi.p2m0111DCPHPHM1XXX031920828990834787223040503011520448165303608540410005802PH5909BuzhuYazi6011Baguio city62380011ph.ppmi.p2m051921653329512971919506304EC47"
+
+	g := generator.NewDeepLinkGenerator()
+	result, err := g.GenerateWithValidation(qrCode, &models.DeepLinkOptions{})
+	if err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+
+	if result.QRFormat != models.QRFormatNew {
+		t.Errorf("qrFormat 错误: got %q, want %q", result.QRFormat, models.QRFormatNew)
+	}
+	if result.FormatDetection == nil {
+		t.Error("自动识别时应返回 formatDetection")
+	}
+	if !containsParam(result.DeepLink, "shopId", "2165332951297191950") {
+		t.Errorf("shopId 应为订单号, deepLink: %s", result.DeepLink)
+	}
+
+	// 显式指定旧格式时不交换
+	result, err = g.GenerateWithValidation(qrCode, &models.DeepLinkOptions{QRFormat: models.QRFormatLegacy})
+	if err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	if result.QRFormat != models.QRFormatLegacy || result.FormatDetection != nil {
+		t.Errorf("显式格式应直接采用: got %q, detection=%v", result.QRFormat, result.FormatDetection)
+	}
+	if !containsParam(result.DeepLink, "shopId", "2082899083478722304") {
+		t.Errorf("shopId 应为 UID, deepLink: %s", result.DeepLink)
+	}
+}
+
+func TestGenerateStrategiesOverlay(t *testing.T) {
+	qrCode := p2mQR
+
+	p := parser.NewEMVCoParser()
+	data, err := p.Parse(qrCode)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+
+	base := &models.DeepLinkOptions{OrderID: "ORDER-1", MerchantName: "BASE"}
+	strategies := []models.Strategy{
+		{Name: "b_static", Options: models.DeepLinkOptions{PaymentType: models.PaymentTypeStatic}},
+		{Name: "a_renamed", Options: models.DeepLinkOptions{MerchantName: "OVERRIDE", RedirectURL: "https://shop.example/ok"}},
+	}
+
+	g := generator.NewDeepLinkGenerator()
+	g.SetURLPolicy(models.URLPolicy{Hosts: []string{"shop.example"}})
+	results := g.GenerateStrategies(data, base, strategies)
+	if len(results) != 2 || results[0].Name != "b_static" || results[1].Name != "a_renamed" {
+		t.Fatalf("结果应按策略顺序返回: %+v", results)
+	}
+
+	if !containsParam(results[0].DeepLink, "param3", "99960005~ph.ppmi.p2m~~~001") {
+		t.Errorf("b_static 应使用静态支付类型, deepLink: %s", results[0].DeepLink)
+	}
+	if !containsParam(results[0].DeepLink, "orderId", "ORDER-1") || !containsParam(results[0].DeepLink, "merchantName", "BASE") {
+		t.Errorf("b_static 应保留基础选项, deepLink: %s", results[0].DeepLink)
+	}
+	if !containsParam(results[1].DeepLink, "merchantName", "OVERRIDE") || !containsParam(results[1].DeepLink, "redirectUrl", "https://shop.example/ok") {
+		t.Errorf("a_renamed 应覆盖基础选项, deepLink: %s", results[1].DeepLink)
+	}
+	if base.PaymentType != "" || base.MerchantName != "BASE" {
+		t.Errorf("基础选项被修改: %+v", *base)
+	}
+}
+
+func TestGenerateStrategiesPreview(t *testing.T) {
+	data, err := parser.NewEMVCoParser().Parse(p2mQR)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+
+	// 内置策略各不相同；策略结果只是预览，不登记订单、不写审计记录
+	orders := store.NewOrderStore()
+	audited := 0
+	g := generator.NewDeepLinkGenerator()
+	g.SetOrderRegistry(orders)
+	g.SetAuditSink(models.AuditSinkFunc(func(models.AuditRecord) error { audited++; return nil }))
+	g.SetURLPolicy(models.URLPolicy{Hosts: []string{"shop.example.com"}})
+	results := g.GenerateStrategies(data, &models.DeepLinkOptions{
+		OrderID: "ORDER-S1", RedirectURL: "https://shop.example.com/done", NotifyURL: "https://shop.example.com/notify",
+	}, nil)
+	links := make(map[string]bool)
+	for _, r := range results {
+		if !r.Success || links[r.DeepLink] {
+			t.Errorf("策略 %s 生成失败或与其他策略相同: %+v", r.Name, r.DeepLinkResult)
+		}
+		links[r.DeepLink] = true
+	}
+	if len(results) != 3 || results[0].Resolved.OrderID != "" || results[1].Resolved.RedirectURL != "" ||
+		results[1].Resolved.OrderID != "ORDER-S1" || results[2].Resolved.NotifyURL == "" {
+		t.Errorf("策略继承的选项错误: %+v", results)
+	}
+	if _, err := orders.Get("ORDER-S1"); err == nil || audited != 0 {
+		t.Errorf("策略预览不应登记订单或写审计记录: audited=%d", audited)
+	}
+}
+
+func TestGenerateAmountValidation(t *testing.T) {
+	// 动态 QR (01=12)，Tag 54 = 100.00
+	qrCode := p2mQR
+	g := generator.NewDeepLinkGenerator()
+
+	// 与 Tag 54 数值一致时规范化为两位小数
+	result, err := g.GenerateWithValidation(qrCode, &models.DeepLinkOptions{OrderAmount: "100"})
+	if err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	if !containsParam(result.DeepLink, "orderAmount", "100.00") {
+		t.Errorf("orderAmount 应规范化为 100.00, deepLink: %s", result.DeepLink)
+	}
+
+	// 与 Tag 54 不一致
+	_, err = g.GenerateWithValidation(qrCode, &models.DeepLinkOptions{OrderAmount: "50.00"})
+	if !errors.Is(err, models.ErrAmountMismatch) {
+		t.Errorf("应返回 ErrAmountMismatch, got %v", err)
+	}
+
+	// 超出限额（按支付类型）
+	g.SetAmountPolicy(models.AmountPolicy{
+		Default:      models.AmountLimits{Min: 1, Max: models.QRPhMaxAmount},
+		PaymentTypes: map[models.PaymentType]models.AmountLimits{models.PaymentTypeStandard: {Max: 50_00}},
+	})
+	_, err = g.GenerateWithValidation(qrCode, &models.DeepLinkOptions{})
+	if !errors.Is(err, models.ErrAmountRange) {
+		t.Errorf("应返回 ErrAmountRange, got %v", err)
+	}
+
+	// 商户限额优先于支付类型
+	g.SetAmountPolicy(models.AmountPolicy{
+		PaymentTypes: map[models.PaymentType]models.AmountLimits{models.PaymentTypeStandard: {Max: 50_00}},
+		Merchants:    map[string]models.AmountLimits{"M-1": {Max: 200_00}},
+	})
+	if _, err := g.GenerateWithValidation(qrCode, &models.DeepLinkOptions{MerchantID: "M-1"}); err != nil {
+		t.Errorf("商户限额内应成功: %v", err)
+	}
+}
+
+func TestCrossBorderQR(t *testing.T) {
+	// PromptPay (泰国) QR: Tag 29 GUID A000000677010111, 货币 764, 国家 TH
+	qrCode := "00020101021229370016A0000006770101110113006681234567853037645406100.005802TH5909SIAM SHOP6007Bangkok63043C35"
+
+	p := parser.NewEMVCoParser()
+	data, err := p.Parse(qrCode)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if data.Network != "PromptPay" {
+		t.Errorf("网络识别错误: got %q", data.Network)
+	}
+	if len(data.MerchantAccounts) != 1 || data.MerchantAccounts[0].Tag != "29" {
+		t.Errorf("商户账户错误: %+v", data.MerchantAccounts)
+	}
+
+	validation := p.Validate(qrCode)
+	if !validation.Valid {
+		t.Errorf("格式有效的跨境 QR 应通过验证: %v", validation.Errors)
+	}
+	if len(validation.Warnings) == 0 {
+		t.Error("跨境 QR 应返回警告")
+	}
+
+	g := generator.NewDeepLinkGenerator()
+	if _, err := g.Generate(data, nil); !errors.Is(err, models.ErrUnsupportedNetwork) {
+		t.Errorf("应拒绝 PromptPay QR, got %v", err)
+	}
+
+	// 仅货币不支持
+	_, err = g.Generate(&models.EMVCoData{RawData: "x", Currency: "702", CountryCode: "PH"}, nil)
+	if !errors.Is(err, models.ErrUnsupportedCurrency) {
+		t.Errorf("应拒绝 SGD 货币, got %v", err)
+	}
+
+	// QR Ph 正常
+	qrph, _ := p.Parse("00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275")
+	if qrph.Network != models.NetworkQRPh {
+		t.Errorf("QR Ph 网络识别错误: got %q", qrph.Network)
+	}
+}
+
+func TestP2PQRCode(t *testing.T) {
+	// QR Ph P2P: Tag 27 GUID com.p2pqrpay, 01=BIC, 04=账号
+	qrCode := "00020101021127580012com.p2pqrpay0111GXCHPHM2XXX0208999644030411091712345675204601653036085802PH5914JUAN DELA CRUZ6006Manila630475ED"
+
+	p := parser.NewEMVCoParser()
+	data, err := p.Parse(qrCode)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if data.QRType != models.QRTypeP2P {
+		t.Errorf("QRType 错误: got %q", data.QRType)
+	}
+	if data.BankCode != "GXCHPHM2XXX" || data.AccountNumber != "09171234567" || data.AccountName != "JUAN DELA CRUZ" {
+		t.Errorf("P2P 账户解析错误: bank=%q, account=%q, name=%q", data.BankCode, data.AccountNumber, data.AccountName)
+	}
+	if data.ShopID != "" {
+		t.Errorf("P2P QR 不应有 ShopID: %q", data.ShopID)
+	}
+
+	// 尚无经过验证的 GCash P2P 转账链接格式，不为 P2P QR 生成链接（指定 profile 也不行）
+	g := generator.NewDeepLinkGenerator()
+	for _, options := range []*models.DeepLinkOptions{{OrderAmount: "250"}, {OrderAmount: "250", Profile: generator.DefaultProfileName}} {
+		if _, err := g.Generate(data, options); !errors.Is(err, models.ErrP2PUnsupported) {
+			t.Errorf("P2P QR 应返回 ErrP2PUnsupported, got %v", err)
+		}
+	}
+
+	// P2M QR 仍使用 P2M 布局
+	p2m, _ := p.Parse("00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275")
+	if p2m.QRType != models.QRTypeP2M {
+		t.Errorf("P2M QRType 错误: got %q", p2m.QRType)
+	}
+}
+
+func TestMCCPolicy(t *testing.T) {
+	p := parser.NewEMVCoParser()
+	data, err := p.Parse("00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275")
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if data.MerchantCategory == "" {
+		t.Errorf("MCC %s 应有分类描述", data.MerchantCategoryCode)
+	}
+
+	// 默认策略拒绝博彩类商户
+	casino := *data
+	casino.MerchantCategoryCode = "7995"
+	g := generator.NewDeepLinkGenerator()
+	if _, err := g.Generate(&casino, nil); !errors.Is(err, models.ErrMCCBlocked) {
+		t.Errorf("博彩类 MCC 应被拒绝, got %v", err)
+	}
+
+	// 自定义规则: 区间匹配，要求订单号
+	policy := models.MCCPolicy{Rules: []models.MCCRule{
+		{Name: "wholesale-order-id", Codes: []string{"5100-5199"}, Action: models.MCCActionRequireOrderID},
+	}}
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("规则校验失败: %v", err)
+	}
+	g.SetMCCPolicy(policy)
+	if _, err := g.Generate(data, nil); !errors.Is(err, models.ErrOrderIDRequired) {
+		t.Errorf("缺少订单号应被拒绝, got %v", err)
+	}
+	if result, err := g.Generate(data, &models.DeepLinkOptions{OrderID: "ORDER-1"}); err != nil || !result.Success {
+		t.Errorf("提供订单号后应成功: %v", err)
+	}
+
+	invalid := models.MCCPolicy{Rules: []models.MCCRule{{Name: "bad", Codes: []string{"79"}, Action: models.MCCActionBlock}}}
+	if err := invalid.Validate(); err == nil {
+		t.Error("无效 MCC 代码应校验失败")
+	}
+}
+
+// containsParam 链接中参数 key 的值是否为 expected
+func containsParam(deepLink, key, expected string) bool {
+	u, err := url.Parse(deepLink)
+	if err != nil {
+		return false
+	}
+	return u.Query().Get(key) == expected
+}
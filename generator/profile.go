@@ -0,0 +1,184 @@
+package generator
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// DefaultProfileName 默认参数布局（Luca 模板）
+const DefaultProfileName = "gcash-p2m-v2"
+
+// placeholderPattern 模板占位符 {name}
+var placeholderPattern = regexp.MustCompile(`\{([A-Za-z]+)\}`)
+
+// DefaultProfiles 内置参数布局
+func DefaultProfiles() []models.ParameterProfile {
+	return []models.ParameterProfile{
+		{
+			Name:        "gcash-p2m-v2",
+			Version:     "2",
+			Description: "当前布局: param5 为 ShopID~MerchantName~TerminalLabel~AcqInfo (4段3波浪，对齐 Luca 模板)",
+			Params:      p2mParams("{shopId}~{merchantName}~{terminalLabel}~{acqInfo}"),
+		},
+		{
+			Name:        "gcash-p2m-v1",
+			Version:     "1",
+			Description: "旧布局: param5 为 ShopID~BillNumber~~~AcqInfo (5段4波浪)",
+			Params:      p2mParams("{shopId}~{billNumber}~~~{acqInfo}"),
+		},
+	}
+}
+
+// p2mParams P2M 支付通用参数，仅 param5 模板因版本而异
+func p2mParams(param5 string) []models.ParameterField {
+	return []models.ParameterField{
+		// 必需参数
+		{Key: "qrCode", Value: "{qrCode}", Always: true},
+		{Key: "bizNo", Value: "{bizNo}", Always: true},
+		{Key: "orderAmount", Value: "{orderAmount}", Always: true},
+		{Key: "qrCodeFormat", Value: "EMVCO", Always: true},
+		{Key: "sub", Value: "p2mpay", Always: true},
+		{Key: "clientId", Value: "{clientId}", Always: true},
+		{Key: "merchantName", Value: "{merchantName}", Always: true},
+
+		// 可选参数 - 只在有值时添加
+		{Key: "merchantId", Value: "{merchantId}"},
+		{Key: "orderId", Value: "{orderId}"},
+		{Key: "tfrbnkcode", Value: "{bankCode}"},
+		{Key: "shopId", Value: "{shopId}"},
+		{Key: "tfrAcctNo", Value: "{shopId}"},
+		{Key: "acqInfo", Value: "{acqInfo}"},
+
+		// 回调 URL
+		{Key: "redirectUrl", Value: "{redirectUrl}"},
+		{Key: "returnUrl", Value: "{redirectUrl}"},
+		{Key: "notifyUrl", Value: "{notifyUrl}"},
+		{Key: "callbackUrl", Value: "{notifyUrl}"},
+
+		// param3 和 param5
+		{Key: "param3", Value: "99960005~ph.ppmi.p2m~~~{paymentType}"},
+		{Key: "param5", Value: param5, Requires: []string{"shopId"}},
+
+		// GCash PAY_QR 需要的额外参数
+		{Key: "merchantCity", Value: "{merchantCity}"},
+		{Key: "merchantCategoryCode", Value: "{merchantCategoryCode}"},
+		{Key: "lucky", Value: "false", Always: true},
+	}
+}
+
+// SetProfiles 替换可用的参数布局及默认布局，需在开始生成前调用
+func (g *DeepLinkGenerator) SetProfiles(profiles []models.ParameterProfile, defaultName string) error {
+	registry := make(map[string]models.ParameterProfile, len(profiles))
+	for _, p := range profiles {
+		if err := ValidateProfile(p); err != nil {
+			return err
+		}
+		if _, exists := registry[p.Name]; exists {
+			return fmt.Errorf("参数布局名称重复: %s", p.Name)
+		}
+		registry[p.Name] = p
+	}
+	if defaultName == "" {
+		defaultName = DefaultProfileName
+	}
+	if _, ok := registry[defaultName]; !ok {
+		return fmt.Errorf("默认参数布局不存在: %s", defaultName)
+	}
+
+	g.profiles = registry
+	g.defaultProfile = defaultName
+	return nil
+}
+
+// Profiles 返回当前可用的参数布局
+func (g *DeepLinkGenerator) Profiles() map[string]models.ParameterProfile {
+	return g.profiles
+}
+
+// ValidateProfile 校验参数布局：名称、参数键非空，占位符均为已知字段
+func ValidateProfile(p models.ParameterProfile) error {
+	if p.Name == "" {
+		return fmt.Errorf("参数布局缺少 name")
+	}
+	if len(p.Params) == 0 {
+		return fmt.Errorf("参数布局 %s 没有参数", p.Name)
+	}
+
+	known := profileFields(&models.ResolvedOptions{})
+	for _, field := range p.Params {
+		if field.Key == "" {
+			return fmt.Errorf("参数布局 %s 存在空参数名", p.Name)
+		}
+		names := field.Requires
+		for _, m := range placeholderPattern.FindAllStringSubmatch(field.Value, -1) {
+			names = append(names, m[1])
+		}
+		for _, name := range names {
+			if _, ok := known[name]; !ok {
+				return fmt.Errorf("参数布局 %s 的参数 %s 引用了未知字段: %s", p.Name, field.Key, name)
+			}
+		}
+	}
+	return nil
+}
+
+// profileFields 模板可引用的字段
+func profileFields(options *models.ResolvedOptions) map[string]string {
+	return map[string]string{
+		"qrCode":               options.QRCode,
+		"bizNo":                options.BizNo,
+		"orderAmount":          options.OrderAmount,
+		"clientId":             options.ClientID,
+		"merchantName":         options.MerchantName,
+		"merchantId":           options.MerchantID,
+		"orderId":              options.OrderID,
+		"paymentType":          string(options.PaymentType),
+		"redirectUrl":          options.RedirectURL,
+		"notifyUrl":            options.NotifyURL,
+		"shopId":               options.ShopID,
+		"bankCode":             options.BankCode,
+		"billNumber":           options.BillNumber,
+		"acqInfo":              options.AcqInfo,
+		"terminalLabel":        options.TerminalLabel,
+		"merchantCity":         options.MerchantCity,
+		"merchantCategoryCode": options.MerchantCategoryCode,
+	}
+}
+
+// buildParameters 按参数布局构建 URL 参数
+func (g *DeepLinkGenerator) buildParameters(profile models.ParameterProfile, options *models.ResolvedOptions) url.Values {
+	fields := profileFields(options)
+	pairs := make([]string, 0, len(fields)*2)
+	for name, value := range fields {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	replacer := strings.NewReplacer(pairs...)
+
+	values := url.Values{}
+	for _, field := range profile.Params {
+		if !requiresMet(field.Requires, fields) {
+			continue
+		}
+		value := replacer.Replace(field.Value)
+		if field.Always {
+			values.Add(field.Key, value)
+		} else {
+			g.addIfNotEmpty(values, field.Key, value)
+		}
+	}
+	return values
+}
+
+// requiresMet 依赖的字段是否均非空
+func requiresMet(requires []string, fields map[string]string) bool {
+	for _, name := range requires {
+		if fields[name] == "" {
+			return false
+		}
+	}
+	return true
+}
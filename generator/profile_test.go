@@ -0,0 +1,73 @@
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/generator"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	"github.com/qinyuanmao/gcash-deeplink/parser"
+)
+
+func TestParameterProfiles(t *testing.T) {
+	qrCode := "00020101021228790011ph.ppmi.p2m0111PAEYPHM2XXX0324VkHUE2Fz8Ee2YxnTVPX34TZs0410030300288605030105204739953036085406100.005802PH5916NEXA ONLINE SHOP6013General Trias62430012ph.ppmi.qrph0306wWMBdH05062110000803***88440012ph.ppmi.qrph0124VkHUE2Fz8Ee2YxnTVPX34TZs63041C3C"
+
+	// 示例 QR 的收单机构已收录，校验不应提示未知 BIC
+	if validation := parser.NewEMVCoParser().Validate(qrCode); !validation.Valid || len(validation.Warnings) != 0 {
+		t.Errorf("示例 QR 不应有警告: %+v", validation)
+	}
+	if bank, ok := models.DefaultBankDirectory().Lookup("PAEYPHM2XXX"); !ok || bank.Name == "" {
+		t.Error("应收录 PAEYPHM2XXX")
+	}
+
+	g := generator.NewDeepLinkGenerator()
+
+	// 默认布局: ShopID~MerchantName~TerminalLabel~AcqInfo
+	result, err := g.GenerateWithValidation(qrCode, &models.DeepLinkOptions{})
+	if err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	if result.Resolved.Profile != generator.DefaultProfileName {
+		t.Errorf("默认 profile 错误: got %q", result.Resolved.Profile)
+	}
+	if !containsParam(result.DeepLink, "param5", "VkHUE2Fz8Ee2YxnTVPX34TZs~NEXA ONLINE SHOP~~211000") {
+		t.Errorf("v2 param5 错误, deepLink: %s", result.DeepLink)
+	}
+
+	// 旧布局: ShopID~BillNumber~~~AcqInfo
+	result, err = g.GenerateWithValidation(qrCode, &models.DeepLinkOptions{Profile: "gcash-p2m-v1"})
+	if err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	if !containsParam(result.DeepLink, "param5", "VkHUE2Fz8Ee2YxnTVPX34TZs~wWMBdH~~~211000") {
+		t.Errorf("v1 param5 错误, deepLink: %s", result.DeepLink)
+	}
+
+	// 自定义布局
+	custom := models.ParameterProfile{
+		Name: "custom",
+		Params: []models.ParameterField{
+			{Key: "qrCode", Value: "{qrCode}", Always: true},
+			{Key: "param3", Value: "X~{paymentType}"},
+			{Key: "lucky", Value: "true", Always: true},
+		},
+	}
+	if err := g.SetProfiles(append(generator.DefaultProfiles(), custom), "custom"); err != nil {
+		t.Fatalf("SetProfiles 失败: %v", err)
+	}
+	result, err = g.GenerateWithValidation(qrCode, &models.DeepLinkOptions{})
+	if err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	if !containsParam(result.DeepLink, "param3", "X~000") || !containsParam(result.DeepLink, "lucky", "true") || containsParam(result.DeepLink, "sub", "p2mpay") {
+		t.Errorf("自定义布局未生效, deepLink: %s", result.DeepLink)
+	}
+
+	// 未知 profile 与未知占位符
+	if _, err := g.GenerateWithValidation(qrCode, &models.DeepLinkOptions{Profile: "missing"}); err == nil {
+		t.Error("未知 profile 应返回错误")
+	}
+	bad := models.ParameterProfile{Name: "bad", Params: []models.ParameterField{{Key: "x", Value: "{nope}"}}}
+	if err := generator.ValidateProfile(bad); err == nil {
+		t.Error("未知占位符应校验失败")
+	}
+}
@@ -2,4 +2,15 @@ module github.com/qinyuanmao/gcash-deeplink
 
 go 1.21
 
-require go.mercari.io/go-emv-code v0.1.5
+require (
+	go.mercari.io/go-emv-code v0.1.5
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237
+	google.golang.org/grpc v1.64.1
+	google.golang.org/protobuf v1.34.1
+)
+
+require (
+	golang.org/x/net v0.26.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+)
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/qinyuanmao/gcash-deeplink/auth"
+	"github.com/qinyuanmao/gcash-deeplink/i18n"
+	"github.com/qinyuanmao/gcash-deeplink/logging"
+	"github.com/qinyuanmao/gcash-deeplink/middleware"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	deeplinkv1 "github.com/qinyuanmao/gcash-deeplink/proto/deeplink/v1"
+)
+
+// grpcErrorDomain gRPC 错误 ErrorInfo.domain
+const grpcErrorDomain = "gcash-deeplink"
+
+// grpcScopes 各方法所需的 API Key scope；健康检查与反射无需认证
+var grpcScopes = map[string]auth.Scope{
+	deeplinkv1.DeepLinkService_Parse_FullMethodName:          auth.ScopeParse,
+	deeplinkv1.DeepLinkService_Validate_FullMethodName:       auth.ScopeParse,
+	deeplinkv1.DeepLinkService_DecodeDeepLink_FullMethodName: auth.ScopeParse,
+	deeplinkv1.DeepLinkService_Generate_FullMethodName:       auth.ScopeGenerate,
+	deeplinkv1.DeepLinkService_GenerateBatch_FullMethodName:  auth.ScopeGenerate,
+}
+
+// grpcServer gRPC API 服务：DeepLinkService、健康检查（grpc.health.v1）与服务反射
+// 使用独立端口，与 HTTP 服务共用解析器、生成器、API Key 与订单存储
+type grpcServer struct {
+	deeplinkv1.UnimplementedDeepLinkServiceServer
+
+	addr            string
+	certFile        string // 与 keyFile 同时设置时启用 TLS
+	keyFile         string
+	shutdownTimeout time.Duration
+
+	// 与 HTTP 服务相同的防护参数（limits）
+	byIP     *middleware.Limiter
+	byKey    *middleware.Limiter
+	maxBatch int
+
+	health *health.Server
+}
+
+// newGRPCServer 创建 gRPC 服务；开始监听前健康状态为 NOT_SERVING
+func newGRPCServer(addr string) *grpcServer {
+	limits := appConfig.Limits
+	s := &grpcServer{
+		addr:            addr,
+		shutdownTimeout: 30 * time.Second,
+		byIP:            middleware.NewLimiter(limits.IPRate, limits.IPBurst),
+		byKey:           middleware.NewLimiter(limits.KeyRate, limits.KeyBurst),
+		maxBatch:        limits.MaxBatch,
+		health:          health.NewServer(),
+	}
+	s.setServing(healthpb.HealthCheckResponse_NOT_SERVING)
+	return s
+}
+
+// setServing 设置整体与 DeepLinkService 的健康状态
+func (s *grpcServer) setServing(st healthpb.HealthCheckResponse_ServingStatus) {
+	s.health.SetServingStatus("", st)
+	s.health.SetServingStatus(deeplinkv1.DeepLinkService_ServiceDesc.ServiceName, st)
+}
+
+// newServer 创建带限流、认证、请求 ID 与访问日志拦截器的 grpc.Server 并注册服务
+// 单条消息上限为 maxBodyBytes，每个连接的并发流数上限为 maxConcurrent
+func (s *grpcServer) newServer() (*grpc.Server, error) {
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(s.unaryInterceptor),
+		grpc.ChainStreamInterceptor(s.streamInterceptor),
+	}
+	if limit := appConfig.Limits.MaxBodyBytes; limit > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(int(limit)))
+	}
+	if limit := appConfig.Limits.MaxConcurrent; limit > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(uint32(limit)))
+	}
+	if s.certFile != "" && s.keyFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(s.certFile, s.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("加载 gRPC TLS 证书失败: %w", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	server := grpc.NewServer(opts...)
+	deeplinkv1.RegisterDeepLinkServiceServer(server, s)
+	healthpb.RegisterHealthServer(server, s.health)
+	reflection.Register(server)
+	return server, nil
+}
+
+// Run 监听 addr 并提供服务，直到 ctx 取消或监听失败
+func (s *grpcServer) Run(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve 在 ln 上提供服务
+// ctx 取消后健康状态转为 NOT_SERVING，停止接收新请求，并在 shutdownTimeout 内等待处理中的请求完成
+func (s *grpcServer) Serve(ctx context.Context, ln net.Listener) error {
+	s.addr = ln.Addr().String()
+	server, err := s.newServer()
+	if err != nil {
+		ln.Close()
+		return err
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- server.Serve(ln)
+	}()
+	s.setServing(healthpb.HealthCheckResponse_SERVING)
+
+	select {
+	case err := <-errc:
+		s.setServing(healthpb.HealthCheckResponse_NOT_SERVING)
+		return err
+	case <-ctx.Done():
+	}
+
+	s.health.Shutdown()
+	slog.Info("正在关闭 gRPC 服务，等待处理中的请求完成", "timeout", s.shutdownTimeout.String())
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(s.shutdownTimeout):
+		server.Stop()
+		<-stopped
+	}
+	if err := <-errc; err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// unaryInterceptor 请求 ID、限流、API Key 认证与访问日志
+func (s *grpcServer) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	ctx = grpcRequestContext(ctx)
+	err := s.limit(ctx, info.FullMethod)
+	if err == nil {
+		ctx, err = s.authenticate(ctx, info.FullMethod)
+	}
+	var resp interface{}
+	if err == nil {
+		resp, err = handler(ctx, req)
+	}
+	logGRPC(ctx, info.FullMethod, err, start)
+	return resp, err
+}
+
+// streamInterceptor 流式方法的请求 ID、限流、API Key 认证与访问日志
+func (s *grpcServer) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx := grpcRequestContext(ss.Context())
+	err := s.limit(ctx, info.FullMethod)
+	if err == nil {
+		ctx, err = s.authenticate(ctx, info.FullMethod)
+	}
+	if err == nil {
+		err = handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+	logGRPC(ctx, info.FullMethod, err, start)
+	return err
+}
+
+// contextStream 替换 Context 的 ServerStream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// grpcRequestContext 沿用元数据 x-request-id（合法时）或生成新 ID，并通过响应头回写
+func grpcRequestContext(ctx context.Context) context.Context {
+	id := logging.NormalizeRequestID(metadataValue(ctx, logging.HeaderRequestID))
+	_ = grpc.SetHeader(ctx, metadata.Pairs(logging.HeaderRequestID, id))
+	return logging.WithRequest(ctx, appLogger, id)
+}
+
+// limit 与 HTTP 相同的令牌桶限流：按对端 IP 与请求携带的 API Key 计数
+// 健康检查与反射不限流，便于负载均衡探测
+func (s *grpcServer) limit(ctx context.Context, method string) error {
+	if _, ok := grpcScopes[method]; !ok {
+		return nil
+	}
+	ok, _ := s.byIP.Allow(peerIP(ctx))
+	if token := grpcToken(ctx); ok && token != "" {
+		ok, _ = s.byKey.Allow(token)
+	}
+	if !ok {
+		return grpcError(ctx, &models.Error{Code: models.CodeRateLimited, Message: "请求过于频繁，请稍后重试"})
+	}
+	return nil
+}
+
+// checkBatch 单个 GenerateBatch 流的请求数不超过 maxBatch（<= 0 不限制）
+func (s *grpcServer) checkBatch(ctx context.Context, count int) error {
+	if s.maxBatch <= 0 || count <= s.maxBatch {
+		return nil
+	}
+	return grpcError(ctx, &models.Error{
+		Code:    models.CodeBatchTooLarge,
+		Message: fmt.Sprintf("批量请求最多 %d 条", s.maxBatch),
+		Params:  models.Params{"limit": strconv.Itoa(s.maxBatch)},
+	})
+}
+
+// peerIP 对端 IP；gRPC 不经过 HTTP 反向代理，不读取 X-Forwarded-For
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// grpcToken 元数据 authorization: Bearer <key> 或 x-api-key 中的 API Key
+func grpcToken(ctx context.Context) string {
+	token := metadataValue(ctx, auth.HeaderAPIKey)
+	if bearer, ok := strings.CutPrefix(metadataValue(ctx, "authorization"), "Bearer "); ok {
+		token = bearer
+	}
+	return strings.TrimSpace(token)
+}
+
+// authenticate 校验方法所需的 scope；未启用认证或方法无需认证时直接通过
+func (s *grpcServer) authenticate(ctx context.Context, method string) (context.Context, error) {
+	scope, ok := grpcScopes[method]
+	if apiKeys == nil || !ok {
+		return ctx, nil
+	}
+	k, err := apiKeys.Authenticate(grpcToken(ctx))
+	if err != nil {
+		return ctx, grpcError(ctx, &models.Error{Code: models.CodeUnauthorized, Message: err.Error(), Err: err})
+	}
+	if !k.HasScope(scope) {
+		return ctx, grpcError(ctx, &models.Error{Code: models.CodeForbidden, Message: auth.ErrScopeDenied.Error(), Err: auth.ErrScopeDenied})
+	}
+	return auth.WithKey(ctx, k), nil
+}
+
+// metadataValue 请求元数据中 key（不区分大小写）的第一个值
+func metadataValue(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, strings.ToLower(key)); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// logGRPC 访问日志，内部错误记为 error 级别
+func logGRPC(ctx context.Context, method string, err error, start time.Time) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	if code == codes.Internal || code == codes.Unknown {
+		level = slog.LevelError
+	}
+	logging.FromContext(ctx).Log(ctx, level, "grpc request",
+		"method", method,
+		"code", code.String(),
+		"duration_ms", float64(time.Since(start).Microseconds())/1000,
+	)
+}
+
+// grpcLang 按元数据 accept-language 选择错误消息语言
+func grpcLang(ctx context.Context) i18n.Lang {
+	return i18n.Negotiate(metadataValue(ctx, "accept-language"))
+}
+
+// grpcError 转换为 gRPC 状态：消息按 accept-language 渲染，错误码与参数写入 ErrorInfo
+func grpcError(ctx context.Context, err error) error {
+	code, message := i18n.Render(err, grpcLang(ctx))
+	st := status.New(grpcCode(code), message)
+	info := &errdetails.ErrorInfo{Reason: code, Domain: grpcErrorDomain}
+	var e *models.Error
+	if errors.As(err, &e) && len(e.Params) > 0 {
+		info.Metadata = e.Params
+	}
+	if detailed, err := st.WithDetails(info); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// grpcCode 错误码对应的 gRPC 状态码，未列出的均为 InvalidArgument
+func grpcCode(code string) codes.Code {
+	switch code {
+	case models.CodeUnauthorized:
+		return codes.Unauthenticated
+	case models.CodeForbidden:
+		return codes.PermissionDenied
+	case models.CodeNotFound, models.CodeOrderNotFound, models.CodeUnknownBank:
+		return codes.NotFound
+	case models.CodeConflict, models.CodeOrderExists:
+		return codes.AlreadyExists
+	case models.CodeOrderClosed, models.CodeOrderExpired, models.CodeOrderInvalidTransition:
+		return codes.FailedPrecondition
+	case models.CodeRateLimited, models.CodePayloadTooLarge, models.CodeBatchTooLarge:
+		return codes.ResourceExhausted
+	case models.CodeUnavailable:
+		return codes.Unavailable
+	case models.CodeInternal, models.CodeAuditFailed:
+		return codes.Internal
+	}
+	return codes.InvalidArgument
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/qinyuanmao/gcash-deeplink/auth"
+	"github.com/qinyuanmao/gcash-deeplink/generator"
+	"github.com/qinyuanmao/gcash-deeplink/i18n"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	deeplinkv1 "github.com/qinyuanmao/gcash-deeplink/proto/deeplink/v1"
+)
+
+// Parse 解析 QR Code
+func (s *grpcServer) Parse(ctx context.Context, req *deeplinkv1.ParseRequest) (*deeplinkv1.ParseResponse, error) {
+	qrCode, err := decodeRequestQR(req.GetQrCode(), req.GetEncoding())
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	data, err := newParser(ctx).Parse(qrCode)
+	if err != nil {
+		return nil, grpcError(ctx, withCode(err, models.CodeParseFailed))
+	}
+	return &deeplinkv1.ParseResponse{Data: emvcoDataProto(data)}, nil
+}
+
+// Validate 验证 QR Code；errors 与 warnings 按 accept-language 翻译
+func (s *grpcServer) Validate(ctx context.Context, req *deeplinkv1.ValidateRequest) (*deeplinkv1.ValidateResponse, error) {
+	qrCode, err := decodeRequestQR(req.GetQrCode(), req.GetEncoding())
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	validation := newParser(ctx).Validate(qrCode)
+	i18n.LocalizeValidation(validation, grpcLang(ctx))
+	return &deeplinkv1.ValidateResponse{
+		Valid:        validation.Valid,
+		Errors:       validation.Errors,
+		Codes:        validation.Codes,
+		Warnings:     validation.Warnings,
+		WarningCodes: validation.WarningCodes,
+	}, nil
+}
+
+// Generate 生成 Deep Link
+func (s *grpcServer) Generate(ctx context.Context, req *deeplinkv1.GenerateRequest) (*deeplinkv1.GenerateResponse, error) {
+	resp, err := s.generate(ctx, newGenerator(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp, nil
+}
+
+// GenerateBatch 按请求顺序逐个生成并返回结果；单个请求失败时在结果中返回 error，不中断流
+// 超过 maxBatch 条时以 RESOURCE_EXHAUSTED 结束流，已返回的结果仍然有效
+func (s *grpcServer) GenerateBatch(stream deeplinkv1.DeepLinkService_GenerateBatchServer) error {
+	ctx := stream.Context()
+	g := newGenerator(ctx)
+	for index := int32(0); ; index++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.checkBatch(ctx, int(index)+1); err != nil {
+			return err
+		}
+
+		resp := &deeplinkv1.GenerateBatchResponse{Index: index}
+		if result, err := s.generate(ctx, g, req); err != nil {
+			resp.Outcome = &deeplinkv1.GenerateBatchResponse_Error{Error: errorProto(ctx, err)}
+		} else {
+			resp.Outcome = &deeplinkv1.GenerateBatchResponse_Result{Result: result}
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// generate 与 HTTP /v1/generate 相同：校验商户权限后生成链接（生成器登记带订单号的链接）
+func (s *grpcServer) generate(ctx context.Context, g *generator.DeepLinkGenerator, req *deeplinkv1.GenerateRequest) (*deeplinkv1.GenerateResponse, error) {
+	options := deepLinkOptions(req.GetOptions())
+	if err := auth.CheckMerchant(ctx, options.MerchantID); err != nil {
+		return nil, withCode(err, models.CodeForbidden)
+	}
+
+	qrCode, err := decodeRequestQR(req.GetQrCode(), req.GetEncoding())
+	if err != nil {
+		return nil, err
+	}
+	result, err := g.GenerateWithValidation(qrCode, options)
+	if err != nil {
+		return nil, withCode(err, models.CodeGenerateFailed)
+	}
+	return generateResponseProto(result), nil
+}
+
+// decodeRequestQR 按请求声明的 encoding 解码 qrCode（与 HTTP 接口相同，见 models.DecodeInput）
+func decodeRequestQR(qrCode, encoding string) (string, error) {
+	input, err := models.DecodeInput(qrCode, models.QREncoding(encoding))
+	if err != nil {
+		return "", err
+	}
+	return input.QRCode, nil
+}
+
+// DecodeDeepLink 解码 Deep Link
+func (s *grpcServer) DecodeDeepLink(ctx context.Context, req *deeplinkv1.DecodeDeepLinkRequest) (*deeplinkv1.DecodeDeepLinkResponse, error) {
+	decoded, err := newGenerator(ctx).DecodeDeepLink(req.GetDeepLink())
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+
+	resp := &deeplinkv1.DecodeDeepLinkResponse{
+		BaseUrl:    decoded.BaseURL,
+		Profile:    decoded.Profile,
+		Fields:     decoded.Fields,
+		ParsedData: emvcoDataProto(decoded.ParsedData),
+	}
+	for _, p := range decoded.Params {
+		resp.Params = append(resp.Params, &deeplinkv1.DeepLinkParam{Key: p.Key, Value: p.Value})
+	}
+	if decoded.ErrorCode != "" {
+		resp.Error = &deeplinkv1.Error{Code: decoded.ErrorCode, Message: decoded.Error}
+		if lang := grpcLang(ctx); lang != i18n.DefaultLang {
+			if msg, ok := i18n.Message(lang, decoded.ErrorCode, nil); ok {
+				resp.Error.Message = msg
+			}
+		}
+	}
+	return resp, nil
+}
+
+// errorProto 单个结果的错误，消息按 accept-language 渲染
+func errorProto(ctx context.Context, err error) *deeplinkv1.Error {
+	code, message := i18n.Render(err, grpcLang(ctx))
+	return &deeplinkv1.Error{Code: code, Message: message}
+}
+
+// deepLinkOptions 请求选项转换为 models.DeepLinkOptions
+func deepLinkOptions(o *deeplinkv1.DeepLinkOptions) *models.DeepLinkOptions {
+	return &models.DeepLinkOptions{
+		OrderAmount:  o.GetOrderAmount(),
+		MerchantID:   o.GetMerchantId(),
+		MerchantName: o.GetMerchantName(),
+		OrderID:      o.GetOrderId(),
+		PaymentType:  models.PaymentType(o.GetPaymentType()),
+		RedirectURL:  o.GetRedirectUrl(),
+		NotifyURL:    o.GetNotifyUrl(),
+		ClientID:     o.GetClientId(),
+		ShopID:       o.GetShopId(),
+		BizNo:        o.GetBizNo(),
+		QRFormat:     models.QRFormat(o.GetQrFormat()),
+		Profile:      o.GetProfile(),
+	}
+}
+
+// deepLinkOptionsProto models.DeepLinkOptions 转换为消息（QRCode 单独返回）
+func deepLinkOptionsProto(o models.DeepLinkOptions) *deeplinkv1.DeepLinkOptions {
+	return &deeplinkv1.DeepLinkOptions{
+		OrderAmount:  o.OrderAmount,
+		MerchantId:   o.MerchantID,
+		MerchantName: o.MerchantName,
+		OrderId:      o.OrderID,
+		PaymentType:  string(o.PaymentType),
+		RedirectUrl:  o.RedirectURL,
+		NotifyUrl:    o.NotifyURL,
+		ClientId:     o.ClientID,
+		ShopId:       o.ShopID,
+		BizNo:        o.BizNo,
+		QrFormat:     string(o.QRFormat),
+		Profile:      o.Profile,
+	}
+}
+
+func generateResponseProto(result *models.DeepLinkResult) *deeplinkv1.GenerateResponse {
+	resp := &deeplinkv1.GenerateResponse{
+		DeepLink:        result.DeepLink,
+		ParsedData:      emvcoDataProto(result.ParsedData),
+		QrFormat:        string(result.QRFormat),
+		FormatDetection: formatDetectionProto(result.FormatDetection),
+		GeneratedAt:     timestamppb.New(result.GeneratedAt),
+	}
+	if r := result.Resolved; r != nil {
+		resp.Resolved = &deeplinkv1.ResolvedOptions{
+			Options:              deepLinkOptionsProto(r.DeepLinkOptions),
+			QrCode:               r.QRCode,
+			QrType:               string(r.QRType),
+			AccountNumber:        r.AccountNumber,
+			AccountName:          r.AccountName,
+			BankCode:             r.BankCode,
+			BillNumber:           r.BillNumber,
+			AcqInfo:              r.AcqInfo,
+			TerminalLabel:        r.TerminalLabel,
+			MerchantCity:         r.MerchantCity,
+			MerchantCategoryCode: r.MerchantCategoryCode,
+			MerchantRedirectUrl:  r.MerchantRedirectURL,
+		}
+	}
+	return resp
+}
+
+func emvcoDataProto(d *models.EMVCoData) *deeplinkv1.EMVCoData {
+	if d == nil {
+		return nil
+	}
+	msg := &deeplinkv1.EMVCoData{
+		Version:              d.Version,
+		InitMethod:           d.InitMethod,
+		Amount:               d.Amount,
+		Currency:             d.Currency,
+		CountryCode:          d.CountryCode,
+		MerchantName:         d.MerchantName,
+		MerchantCity:         d.MerchantCity,
+		MerchantCategoryCode: d.MerchantCategoryCode,
+		MerchantCategory:     d.MerchantCategory,
+		ShopId:               d.ShopID,
+		BankCode:             d.BankCode,
+		BankName:             d.BankName,
+		BankType:             d.BankType,
+		MerchantAccountGuid:  d.MerchantAccountGUID,
+		QrType:               string(d.QRType),
+		AccountNumber:        d.AccountNumber,
+		AccountName:          d.AccountName,
+		Network:              d.Network,
+		AdditionalDataGuid:   d.AdditionalDataGUID,
+		MobileNumber:         d.MobileNumber,
+		OrderId:              d.OrderID,
+		AcqInfo:              d.AcqInfo,
+		TerminalLabel:        d.TerminalLabel,
+		Crc:                  d.CRC,
+		RawData:              d.RawData,
+		FormatDetection:      formatDetectionProto(d.FormatDetection),
+	}
+	for _, a := range d.MerchantAccounts {
+		msg.MerchantAccounts = append(msg.MerchantAccounts, &deeplinkv1.MerchantAccount{Tag: a.Tag, Guid: a.GUID, Network: a.Network})
+	}
+	return msg
+}
+
+func formatDetectionProto(d *models.QRFormatDetection) *deeplinkv1.QRFormatDetection {
+	if d == nil {
+		return nil
+	}
+	return &deeplinkv1.QRFormatDetection{Format: string(d.Format), Confidence: d.Confidence, Reasons: d.Reasons}
+}
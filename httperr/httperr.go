@@ -0,0 +1,65 @@
+// Package httperr 统一的 HTTP 错误响应
+// /v1/ 下为 {"error": {"code": "...", "message": "..."}}；其他路径保持 {"success": false, "error": "...", "code": "..."}
+// 消息按 Accept-Language 渲染为中文、英文或菲律宾语（见 i18n），客户端应按错误码判断
+package httperr
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/qinyuanmao/gcash-deeplink/i18n"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// VersionPrefix 版本化 API 路径前缀
+const VersionPrefix = "/v1/"
+
+// IsV1 请求是否为 /v1 API
+func IsV1(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, VersionPrefix)
+}
+
+// Write 按请求的 API 版本写入错误响应；message 为中文说明，其他语言按 code 查表
+func Write(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	Error(w, r, status, models.NewError(code, message))
+}
+
+// Error 写入 err 对应的错误响应：错误码取自错误链中的 *models.Error，消息按 Accept-Language 渲染
+func Error(w http.ResponseWriter, r *http.Request, status int, err error) {
+	lang := Lang(r)
+	code, message := i18n.Render(err, lang)
+	w.Header().Set("Content-Language", string(lang))
+	w.Header().Add("Vary", "Accept-Language")
+	if IsV1(r) {
+		JSON(w, status, models.ErrorResponse{Error: models.APIError{Code: code, Message: message}})
+		return
+	}
+	JSON(w, status, map[string]interface{}{
+		"success": false,
+		"error":   message,
+		"code":    code,
+	})
+}
+
+// Lang 请求的 Accept-Language 对应的语言
+func Lang(r *http.Request) i18n.Lang {
+	return i18n.Negotiate(r.Header.Get("Accept-Language"))
+}
+
+// MethodNotAllowed 返回 405 并设置 Allow
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request, allowed ...string) {
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	Error(w, r, http.StatusMethodNotAllowed, &models.Error{
+		Code:    models.CodeMethodNotAllowed,
+		Message: "只支持 " + strings.Join(allowed, " / ") + " 请求",
+		Params:  models.Params{"allowed": strings.Join(allowed, ", ")},
+	})
+}
+
+// JSON 写入 JSON 响应
+func JSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,155 @@
+// Package i18n 按 Accept-Language 渲染错误消息（中文、英文、菲律宾语）
+// 中文直接使用错误本身的说明；英文与菲律宾语按错误码查表，并以错误的 Params 填充模板
+package i18n
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// Lang 支持的语言
+type Lang string
+
+const (
+	LangZH  Lang = "zh"  // 中文（默认）
+	LangEN  Lang = "en"  // 英文
+	LangFIL Lang = "fil" // 菲律宾语（Filipino / Tagalog）
+)
+
+// DefaultLang 未指定或不支持的 Accept-Language 使用中文，与原有响应一致
+const DefaultLang = LangZH
+
+// Negotiate 按 Accept-Language 的权重选择语言，如 "en-PH,en;q=0.9,fil;q=0.8"
+func Negotiate(acceptLanguage string) Lang {
+	type candidate struct {
+		lang Lang
+		q    float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if lang, ok := match(tag); ok && q > 0 {
+			candidates = append(candidates, candidate{lang, q})
+		}
+	}
+	if len(candidates) == 0 {
+		return DefaultLang
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].lang
+}
+
+// match 语言标签的主标签对应的语言
+func match(tag string) (Lang, bool) {
+	primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	switch primary {
+	case "zh":
+		return LangZH, true
+	case "en":
+		return LangEN, true
+	case "fil", "tl":
+		return LangFIL, true
+	}
+	return "", false
+}
+
+// Render 错误码与 lang 语言的消息
+// 错误链中没有 *models.Error 时错误码为 models.CodeInternal，消息为 err.Error()
+func Render(err error, lang Lang) (code, message string) {
+	var e *models.Error
+	if !errors.As(err, &e) {
+		return models.CodeInternal, err.Error()
+	}
+	if lang != LangZH {
+		if msg, ok := Message(lang, e.Code, e.Params); ok {
+			return e.Code, msg
+		}
+	}
+	return e.Code, err.Error()
+}
+
+// Message 错误码在 lang 语言下的消息；依次尝试模板，使用第一个参数齐全的模板
+func Message(lang Lang, code string, params models.Params) (string, bool) {
+	return lookup(messages, lang, code, params)
+}
+
+// Validation 验证错误码 / 提示码（ValidationResult.Codes / WarningCodes）在 lang 语言下的消息
+func Validation(lang Lang, code string, params models.Params) (string, bool) {
+	return lookup(validationMessages, lang, code, params)
+}
+
+// LocalizeValidation 将验证结果的 Errors 与 Warnings 翻译为 lang 语言（中文保持原文）
+func LocalizeValidation(result *models.ValidationResult, lang Lang) {
+	if lang == DefaultLang {
+		return
+	}
+	for i, code := range result.Codes {
+		if msg, ok := Validation(lang, code, nil); ok && i < len(result.Errors) {
+			result.Errors[i] = msg
+		}
+	}
+	for i, code := range result.WarningCodes {
+		var params models.Params
+		if i < len(result.WarningParams) {
+			params = result.WarningParams[i]
+		}
+		if msg, ok := Validation(lang, code, params); ok && i < len(result.Warnings) {
+			result.Warnings[i] = msg
+		}
+	}
+}
+
+func lookup(catalog map[string]map[Lang][]string, lang Lang, code string, params models.Params) (string, bool) {
+	for _, tmpl := range catalog[code][lang] {
+		if msg, ok := expand(tmpl, params); ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// expand 替换模板中的 {name}；缺少参数或参数为空时返回 false
+func expand(tmpl string, params models.Params) (string, bool) {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			b.WriteString(tmpl)
+			return b.String(), true
+		}
+		end := strings.IndexByte(tmpl[start:], '}')
+		if end < 0 {
+			b.WriteString(tmpl)
+			return b.String(), true
+		}
+		value := params[tmpl[start+1:start+end]]
+		if value == "" {
+			return "", false
+		}
+		b.WriteString(tmpl[:start])
+		b.WriteString(value)
+		tmpl = tmpl[start+end+1:]
+	}
+}
+
+// Codes 消息表中的全部错误码
+func Codes() []string {
+	codes := make([]string, 0, len(messages))
+	for code := range messages {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
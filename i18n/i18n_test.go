@@ -0,0 +1,34 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/i18n"
+)
+
+func TestNegotiate(t *testing.T) {
+	for header, want := range map[string]i18n.Lang{
+		"":                        i18n.LangZH,
+		"en-US,en;q=0.9":          i18n.LangEN,
+		"fil-PH":                  i18n.LangFIL,
+		"tl":                      i18n.LangFIL,
+		"ja, en;q=0.5, fil;q=0.8": i18n.LangFIL,
+		"zh-CN;q=0.9, en;q=0":     i18n.LangZH,
+		"de":                      i18n.LangZH,
+	} {
+		if got := i18n.Negotiate(header); got != want {
+			t.Errorf("Negotiate(%q) = %s, want %s", header, got, want)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	// 每个错误码都有英文与菲律宾语的通用消息
+	for _, code := range i18n.Codes() {
+		for _, lang := range []i18n.Lang{i18n.LangEN, i18n.LangFIL} {
+			if msg, ok := i18n.Message(lang, code, nil); !ok || msg == "" {
+				t.Errorf("%s 缺少 %s 通用消息", code, lang)
+			}
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package i18n
+
+import "github.com/qinyuanmao/gcash-deeplink/models"
+
+// messages 错误码 → 语言 → 模板（按顺序尝试，最后一个不含参数，作为通用消息）
+var messages = map[string]map[Lang][]string{
+	// API
+	models.CodeInvalidRequest: {
+		LangEN:  {"{field} is required", "Invalid request"},
+		LangFIL: {"Kailangan ang {field}", "Hindi wastong kahilingan"},
+	},
+	models.CodeInvalidJSON: {
+		LangEN:  {"Request body is not valid JSON"},
+		LangFIL: {"Hindi wastong JSON ang laman ng kahilingan"},
+	},
+	models.CodeUnknownField: {
+		LangEN:  {"Unknown field: {field}", "Request body contains an unknown field"},
+		LangFIL: {"Hindi kilalang field: {field}", "May hindi kilalang field sa laman ng kahilingan"},
+	},
+	models.CodeInvalidField: {
+		LangEN:  {"Field {field} must be of type {type}", "Request body contains a field of the wrong type"},
+		LangFIL: {"Dapat na {type} ang uri ng field na {field}", "May field na mali ang uri sa laman ng kahilingan"},
+	},
+	models.CodeInvalidStrategies: {
+		LangEN:  {"Invalid strategies"},
+		LangFIL: {"Hindi wastong mga strategy"},
+	},
+	models.CodeMethodNotAllowed: {
+		LangEN:  {"Method not allowed, use {allowed}", "Method not allowed"},
+		LangFIL: {"Hindi pinapayagan ang method na ito, gamitin ang {allowed}", "Hindi pinapayagan ang method na ito"},
+	},
+	models.CodeUnauthorized: {
+		LangEN:  {"Missing or invalid API key"},
+		LangFIL: {"Wala o hindi wastong API key"},
+	},
+	models.CodeInvalidSignature: {
+		LangEN:  {"Invalid notification signature"},
+		LangFIL: {"Hindi wastong signature ng notification"},
+	},
+	models.CodeForbidden: {
+		LangEN:  {"This API key is not allowed to perform this request"},
+		LangFIL: {"Hindi pinapayagan ang API key na ito para sa kahilingang ito"},
+	},
+	models.CodeNotFound: {
+		LangEN:  {"Not found"},
+		LangFIL: {"Hindi nahanap"},
+	},
+	models.CodeConflict: {
+		LangEN:  {"Request conflicts with the current state"},
+		LangFIL: {"Sumasalungat ang kahilingan sa kasalukuyang estado"},
+	},
+	models.CodeGone: {
+		LangEN:  {"No longer available"},
+		LangFIL: {"Hindi na available"},
+	},
+	models.CodePayloadTooLarge: {
+		LangEN:  {"Request body exceeds the {limit}-byte limit", "Request body is too large"},
+		LangFIL: {"Lumampas ang laman ng kahilingan sa limitasyong {limit} byte", "Masyadong malaki ang laman ng kahilingan"},
+	},
+	models.CodeRateLimited: {
+		LangEN:  {"Too many requests, please try again later"},
+		LangFIL: {"Masyadong maraming kahilingan, pakisubukang muli mamaya"},
+	},
+	models.CodeBatchTooLarge: {
+		LangEN:  {"A batch may contain at most {limit} requests", "Too many requests in one batch"},
+		LangFIL: {"Hanggang {limit} kahilingan lamang ang maaaring isama sa isang batch", "Masyadong maraming kahilingan sa isang batch"},
+	},
+	models.CodeUnavailable: {
+		LangEN:  {"Service is busy or not configured, please try again later"},
+		LangFIL: {"Abala o hindi naka-configure ang serbisyo, pakisubukang muli mamaya"},
+	},
+	models.CodeParseFailed: {
+		LangEN:  {"Failed to parse the QR code"},
+		LangFIL: {"Hindi ma-parse ang QR code"},
+	},
+	models.CodeGenerateFailed: {
+		LangEN:  {"Failed to generate the deep link"},
+		LangFIL: {"Hindi makagawa ng deep link"},
+	},
+	models.CodeInternal: {
+		LangEN:  {"Internal server error"},
+		LangFIL: {"Nagkaroon ng error sa server"},
+	},
+	models.CodeUnknownBank: {
+		LangEN:  {"Unknown BIC: {bic}", "Unknown BIC"},
+		LangFIL: {"Hindi kilalang BIC: {bic}", "Hindi kilalang BIC"},
+	},
+
+	// 解析
+	models.CodeQREmpty: {
+		LangEN:  {"QR code data is empty"},
+		LangFIL: {"Walang laman ang QR code"},
+	},
+	models.CodeUnknownEncoding: {
+		LangEN:  {"Unknown encoding {encoding}, use raw, url or base64", "Unknown encoding, use raw, url or base64"},
+		LangFIL: {"Hindi kilalang encoding na {encoding}, gamitin ang raw, url o base64", "Hindi kilalang encoding, gamitin ang raw, url o base64"},
+	},
+	models.CodeQRDecode: {
+		LangEN:  {"qrCode is not valid {encoding} data", "Failed to decode qrCode"},
+		LangFIL: {"Hindi wastong {encoding} data ang qrCode", "Hindi ma-decode ang qrCode"},
+	},
+	models.CodeQRMalformed: {
+		LangEN:  {"Malformed QR code: invalid length at position {position}", "Malformed QR code data"},
+		LangFIL: {"Sira ang QR code: hindi wastong haba sa posisyon {position}", "Sira ang datos ng QR code"},
+	},
+
+	// 生成
+	models.CodeInvalidDeepLink: {
+		LangEN:  {"Invalid deep link"},
+		LangFIL: {"Hindi wastong deep link"},
+	},
+	models.CodeDataRequired: {
+		LangEN:  {"Parsed QR data is required"},
+		LangFIL: {"Kailangan ang na-parse na datos ng QR code"},
+	},
+	models.CodeUnsupportedQRFormat: {
+		LangEN:  {"Unsupported QR format: {format}", "Unsupported QR format"},
+		LangFIL: {"Hindi suportadong QR format: {format}", "Hindi suportadong QR format"},
+	},
+	models.CodeUnknownProfile: {
+		LangEN:  {"Unknown parameter profile: {profile}", "Unknown parameter profile"},
+		LangFIL: {"Hindi kilalang parameter profile: {profile}", "Hindi kilalang parameter profile"},
+	},
+	models.CodeP2PUnsupported: {
+		LangEN:  {"Deep links for P2P transfer QR codes are not supported yet"},
+		LangFIL: {"Hindi pa suportado ang deep link para sa P2P transfer QR code"},
+	},
+	models.CodeAuditFailed: {
+		LangEN:  {"Failed to write the audit record"},
+		LangFIL: {"Hindi maisulat ang audit record"},
+	},
+	models.CodeUnsupportedNetwork: {
+		LangEN:  {"GCash cannot settle QR codes from the {network} network", "GCash cannot settle QR codes from this payment network"},
+		LangFIL: {"Hindi kayang i-settle ng GCash ang QR code mula sa {network} network", "Hindi kayang i-settle ng GCash ang QR code mula sa payment network na ito"},
+	},
+	models.CodeUnsupportedCurrency: {
+		LangEN:  {"GCash only settles PHP (608), this QR code uses {currency}", "GCash only settles PHP (608)"},
+		LangFIL: {"PHP (608) lamang ang sine-settle ng GCash, {currency} ang gamit ng QR code na ito", "PHP (608) lamang ang sine-settle ng GCash"},
+	},
+	models.CodeUnsupportedCountry: {
+		LangEN:  {"GCash only supports Philippine QR codes, this QR code is from {country}", "GCash only supports Philippine QR codes"},
+		LangFIL: {"QR code mula sa Pilipinas lamang ang suportado ng GCash, galing sa {country} ang QR code na ito", "QR code mula sa Pilipinas lamang ang suportado ng GCash"},
+	},
+	models.CodeAmountFormat: {
+		LangEN:  {"Invalid amount: {amount}", "Invalid amount format"},
+		LangFIL: {"Hindi wastong halaga: {amount}", "Hindi wastong format ng halaga"},
+	},
+	models.CodeAmountRange: {
+		LangEN: {
+			"Amount {amount} is below the minimum of {min}",
+			"Amount {amount} exceeds the maximum of {max}",
+			"Amount is outside the allowed range",
+		},
+		LangFIL: {
+			"Ang halagang {amount} ay mas mababa sa minimum na {min}",
+			"Ang halagang {amount} ay lampas sa maximum na {max}",
+			"Wala sa pinapayagang saklaw ang halaga",
+		},
+	},
+	models.CodeAmountMismatch: {
+		LangEN:  {"Amount {amount} does not match the QR code amount {qrAmount}", "Amount does not match the dynamic QR code"},
+		LangFIL: {"Hindi tugma ang halagang {amount} sa halaga ng QR code na {qrAmount}", "Hindi tugma ang halaga sa dynamic QR code"},
+	},
+	models.CodeMCCBlocked: {
+		LangEN:  {"Payment links are not allowed for merchant category {mcc}", "Payment links are not allowed for this merchant category"},
+		LangFIL: {"Hindi pinapayagan ang payment link para sa merchant category {mcc}", "Hindi pinapayagan ang payment link para sa merchant category na ito"},
+	},
+	models.CodeOrderIDRequired: {
+		LangEN:  {"Merchant category {mcc} requires an order ID", "This merchant category requires an order ID"},
+		LangFIL: {"Kailangan ng order ID para sa merchant category {mcc}", "Kailangan ng order ID para sa merchant category na ito"},
+	},
+	models.CodeInvalidURL: {
+		LangEN:  {"Invalid callback URL: {url}", "Invalid callback URL"},
+		LangFIL: {"Hindi wastong callback URL: {url}", "Hindi wastong callback URL"},
+	},
+	models.CodeURLNotAllowed: {
+		LangEN:  {"Callback URL host is not allowed: {host}", "Callback URL host is not allowed"},
+		LangFIL: {"Hindi pinapayagan ang host ng callback URL: {host}", "Hindi pinapayagan ang host ng callback URL"},
+	},
+
+	// 订单
+	models.CodeOrderNotFound: {
+		LangEN:  {"Order not found: {orderId}", "Order not found"},
+		LangFIL: {"Hindi nahanap ang order: {orderId}", "Hindi nahanap ang order"},
+	},
+	models.CodeOrderExists: {
+		LangEN:  {"Order {orderId} already exists and payment has started", "Order already exists and payment has started"},
+		LangFIL: {"Umiiral na ang order {orderId} at nagsimula na ang pagbabayad", "Umiiral na ang order at nagsimula na ang pagbabayad"},
+	},
+	models.CodeOrderExpired: {
+		LangEN:  {"Order {orderId} has expired", "Order has expired"},
+		LangFIL: {"Nag-expire na ang order {orderId}", "Nag-expire na ang order"},
+	},
+	models.CodeOrderClosed: {
+		LangEN:  {"Order {orderId} is already completed", "Order is already completed"},
+		LangFIL: {"Tapos na ang order {orderId}", "Tapos na ang order"},
+	},
+	models.CodeOrderInvalidTransition: {
+		LangEN:  {"Invalid status change for order {orderId}", "Invalid order status change"},
+		LangFIL: {"Hindi wastong pagbabago ng status ng order {orderId}", "Hindi wastong pagbabago ng status ng order"},
+	},
+	models.CodeNotificationStatus: {
+		LangEN:  {"Unknown notification status: {status}", "Unknown notification status"},
+		LangFIL: {"Hindi kilalang status ng notification: {status}", "Hindi kilalang status ng notification"},
+	},
+}
+
+// validationMessages 验证错误码与提示码（ValidationResult.Codes / WarningCodes）的消息
+var validationMessages = map[string]map[Lang][]string{
+	models.ValidationEmpty: {
+		LangEN:  {"QR code data is empty"},
+		LangFIL: {"Walang laman ang QR code"},
+	},
+	models.ValidationFormat: {
+		LangEN:  {"Invalid TLV format or CRC checksum"},
+		LangFIL: {"Hindi wastong TLV format o CRC checksum"},
+	},
+	models.ValidationCurrencyMissing: {
+		LangEN:  {"Missing tag 53 currency code"},
+		LangFIL: {"Kulang ang currency code sa tag 53"},
+	},
+	models.ValidationCurrencyInvalid: {
+		LangEN:  {"Invalid tag 53 currency code"},
+		LangFIL: {"Hindi wastong currency code sa tag 53"},
+	},
+	models.ValidationAmountInvalid: {
+		LangEN:  {"Tag 54 amount does not match the currency's decimal places"},
+		LangFIL: {"Hindi tugma ang halaga sa tag 54 sa decimal places ng currency"},
+	},
+	models.ValidationCountryMissing: {
+		LangEN:  {"Missing tag 58 country code"},
+		LangFIL: {"Kulang ang country code sa tag 58"},
+	},
+	models.ValidationCountryInvalid: {
+		LangEN:  {"Invalid tag 58 country code"},
+		LangFIL: {"Hindi wastong country code sa tag 58"},
+	},
+
+	// 提示
+	models.ValidationCurrencyUnknown: {
+		LangEN:  {"Unknown tag 53 currency code: {currency}", "Unknown tag 53 currency code"},
+		LangFIL: {"Hindi kilalang currency code sa tag 53: {currency}", "Hindi kilalang currency code sa tag 53"},
+	},
+	models.ValidationCurrencyUnsupported: {
+		LangEN:  {"Currency is {currency}, GCash only settles PHP", "GCash only settles PHP"},
+		LangFIL: {"{currency} ang currency, PHP lamang ang sine-settle ng GCash", "PHP lamang ang sine-settle ng GCash"},
+	},
+	models.ValidationCountryUnknown: {
+		LangEN:  {"Unknown tag 58 country code: {country}", "Unknown tag 58 country code"},
+		LangFIL: {"Hindi kilalang country code sa tag 58: {country}", "Hindi kilalang country code sa tag 58"},
+	},
+	models.ValidationCountryUnsupported: {
+		LangEN:  {"Country is {country}, GCash only supports Philippine QR codes", "GCash only supports Philippine QR codes"},
+		LangFIL: {"{country} ang bansa, QR code mula sa Pilipinas lamang ang suportado ng GCash", "QR code mula sa Pilipinas lamang ang suportado ng GCash"},
+	},
+	models.ValidationCurrencyMismatch: {
+		LangEN:  {"Currency {currency} is not the local currency of {country}", "Currency does not match the country"},
+		LangFIL: {"Hindi lokal na currency ng {country} ang {currency}", "Hindi tugma ang currency sa bansa"},
+	},
+	models.ValidationNetworkUnsupported: {
+		LangEN:  {"QR code belongs to the {network} network, GCash cannot settle it", "GCash cannot settle QR codes from this payment network"},
+		LangFIL: {"Kabilang ang QR code sa {network} network, hindi ito kayang i-settle ng GCash", "Hindi kayang i-settle ng GCash ang QR code mula sa payment network na ito"},
+	},
+	models.ValidationAcquirerUnknown: {
+		LangEN:  {"Unknown acquirer BIC: {bic}", "Unknown acquirer BIC"},
+		LangFIL: {"Hindi kilalang acquirer BIC: {bic}", "Hindi kilalang acquirer BIC"},
+	},
+}
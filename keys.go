@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/qinyuanmao/gcash-deeplink/auth"
+)
+
+// keysReloadInterval 检查 API Key 文件修改时间的间隔
+const keysReloadInterval = 10 * time.Second
+
+// runKeysCommand API Key 管理子命令
+//
+//	keys add -name NAME -scopes parse,generate [-merchants M1,M2]
+//	keys list
+//	keys revoke KEY_ID
+func runKeysCommand(args []string) error {
+	fs := flag.NewFlagSet("keys", flag.ContinueOnError)
+	file := fs.String("file", appConfig.APIKeysFile, "API Key 文件（默认使用配置中的 apiKeysFile）")
+	name := fs.String("name", "", "名称（add）")
+	scopes := fs.String("scopes", "", "权限范围，逗号分隔: parse,generate,metrics,admin（add）")
+	merchants := fs.String("merchants", "", "允许的 merchantId，逗号分隔，留空不限（add）")
+
+	if len(args) == 0 {
+		return errors.New("用法: keys add|list|revoke [选项]")
+	}
+	action := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("请通过 -file 或配置 apiKeysFile 指定 API Key 文件")
+	}
+
+	store, err := auth.LoadKeyStore(*file)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "add":
+		var parsed []auth.Scope
+		for _, s := range splitList(*scopes) {
+			scope, err := auth.ParseScope(s)
+			if err != nil {
+				return err
+			}
+			parsed = append(parsed, scope)
+		}
+		token, key, err := store.Create(*name, parsed, splitList(*merchants))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("已创建 %s (%s)\n", key.ID, key.Name)
+		fmt.Printf("API Key（只显示一次，请妥善保存）:\n%s\n", token)
+	case "list":
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tSCOPES\tMERCHANTS\tCREATED\tSTATUS")
+		for _, k := range store.List() {
+			status := "active"
+			if k.Revoked {
+				status = "revoked"
+			}
+			scopes := make([]string, len(k.Scopes))
+			for i, s := range k.Scopes {
+				scopes[i] = string(s)
+			}
+			merchants := strings.Join(k.Merchants, ",")
+			if merchants == "" {
+				merchants = "*"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", k.ID, k.Name, strings.Join(scopes, ","), merchants, k.CreatedAt.Format("2006-01-02"), status)
+		}
+		return w.Flush()
+	case "revoke":
+		if fs.NArg() != 1 {
+			return errors.New("用法: keys revoke KEY_ID")
+		}
+		if err := store.Revoke(fs.Arg(0)); err != nil {
+			return err
+		}
+		fmt.Printf("已吊销 %s\n", fs.Arg(0))
+		fmt.Printf("运行中的服务将在 %s 内检测到文件变更后生效，也可发送 SIGHUP 立即重新加载\n", keysReloadInterval)
+	default:
+		return fmt.Errorf("未知的 keys 子命令: %s", action)
+	}
+	return nil
+}
+
+// reloadKeys 收到 SIGHUP 时重新加载 API Key 文件，并定期检查文件修改时间，ctx 取消时退出
+// 加载失败时保留当前密钥
+func reloadKeys(ctx context.Context, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		var (
+			reloaded bool
+			err      error
+		)
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			reloaded, err = true, apiKeys.Reload()
+		case <-ticker.C:
+			reloaded, err = apiKeys.ReloadIfChanged()
+		}
+		if err != nil {
+			slog.Error("重新加载 API Key 失败，继续使用当前密钥", "error", err)
+			continue
+		}
+		if reloaded {
+			setMetricsMerchants()
+			slog.Info("已重新加载 API Key", "file", appConfig.APIKeysFile)
+		}
+	}
+}
+
+// splitList 逗号分隔列表，忽略空项
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
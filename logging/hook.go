@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// Hook 将解析、验证与生成记录为结构化日志，实现 models.MetricsHook
+// QR Code 原始数据、收款账号与手机号按 Redact 脱敏；Deep Link 含 QR 数据，不记录
+type Hook struct {
+	Logger *slog.Logger
+	Redact models.RedactionPolicy
+}
+
+var _ models.MetricsHook = Hook{}
+
+// ObserveParse 实现 models.MetricsHook
+func (h Hook) ObserveParse(event models.ParseEvent) {
+	attrs := []slog.Attr{
+		slog.String("outcome", string(event.Outcome)),
+		slog.Float64("duration_ms", milliseconds(event.Duration)),
+	}
+	attrs = appendString(attrs, "qr", h.Redact.QR(event.Input))
+	if data := event.Data; data != nil {
+		attrs = appendString(attrs, "qr_type", string(data.QRType))
+		attrs = appendString(attrs, "network", data.Network)
+		attrs = appendString(attrs, "bank_code", data.BankCode)
+		attrs = appendString(attrs, "merchant_name", data.MerchantName)
+		attrs = appendString(attrs, "mcc", data.MerchantCategoryCode)
+		attrs = appendString(attrs, "amount", data.Amount)
+		attrs = appendString(attrs, "account_number", h.Redact.Account(data.AccountNumber))
+		attrs = appendString(attrs, "mobile_number", h.Redact.Mobile(data.MobileNumber))
+	}
+	h.log(event.Err, "parse", attrs)
+}
+
+// ObserveValidation 实现 models.MetricsHook
+func (h Hook) ObserveValidation(result *models.ValidationResult) {
+	attrs := []slog.Attr{slog.Bool("valid", result.Valid)}
+	if len(result.Codes) > 0 {
+		attrs = append(attrs, slog.Any("codes", result.Codes))
+	}
+	if len(result.Warnings) > 0 {
+		attrs = append(attrs, slog.Int("warnings", len(result.Warnings)))
+	}
+	h.log(nil, "validate", attrs)
+}
+
+// ObserveGenerate 实现 models.MetricsHook
+func (h Hook) ObserveGenerate(event models.GenerateEvent) {
+	outcome := "success"
+	if event.Err != nil {
+		outcome = "failed"
+	}
+	attrs := []slog.Attr{
+		slog.String("outcome", outcome),
+		slog.Float64("duration_ms", milliseconds(event.Duration)),
+	}
+	if event.Data != nil {
+		attrs = appendString(attrs, "qr", h.Redact.QR(event.Data.RawData))
+	}
+	if r := event.Resolved; r != nil {
+		attrs = appendString(attrs, "payment_type", string(r.PaymentType))
+		attrs = appendString(attrs, "merchant_id", r.MerchantID)
+		attrs = appendString(attrs, "order_id", r.OrderID)
+		attrs = appendString(attrs, "profile", r.Profile)
+		attrs = appendString(attrs, "qr_format", string(r.QRFormat))
+		attrs = appendString(attrs, "amount", r.OrderAmount)
+		attrs = appendString(attrs, "account_number", h.Redact.Account(r.AccountNumber))
+	}
+	h.log(event.Err, "generate", attrs)
+}
+
+// log 成功记为 info，失败记为 warn 并附带错误
+func (h Hook) log(err error, msg string, attrs []slog.Attr) {
+	logger := h.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	logger.LogAttrs(context.Background(), level, msg, attrs...)
+}
+
+// appendString 只追加非空值（脱敏方式为 omit 时值为空）
+func appendString(attrs []slog.Attr, key, value string) []slog.Attr {
+	if value == "" {
+		return attrs
+	}
+	return append(attrs, slog.String(key, value))
+}
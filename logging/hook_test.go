@@ -0,0 +1,47 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/logging"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	"github.com/qinyuanmao/gcash-deeplink/parser"
+)
+
+func TestHookRedaction(t *testing.T) {
+	tlv := func(tag, value string) string { return fmt.Sprintf("%s%02d%s", tag, len(value), value) }
+	qrCode := tlv("00", "01") + tlv("01", "11") +
+		tlv("27", tlv("00", "com.p2pqrpay")+tlv("01", "GXCHPHM2XXX")+tlv("04", "09171234567")) +
+		tlv("53", "608") + tlv("58", "PH") + tlv("59", "JUAN DELA CRUZ") +
+		tlv("62", tlv("02", "09179876543")) + "63040000"
+
+	var buf bytes.Buffer
+	p := parser.NewEMVCoParser()
+	p.SetMetrics(logging.Hook{
+		Logger: slog.New(slog.NewJSONHandler(&buf, nil)),
+		Redact: models.RedactionPolicy{MobileNumber: models.RedactOmit, KeepLast: 3},
+	})
+	data, err := p.Parse(qrCode)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if data.MobileNumber != "09179876543" || data.AccountNumber != "09171234567" {
+		t.Fatalf("应解析手机号与账号: %+v", data)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["outcome"] != "fallback" || entry["account_number"] != "********567" {
+		t.Errorf("账号应掩码: %v", entry)
+	}
+	if _, ok := entry["mobile_number"]; ok || strings.Contains(buf.String(), "9876543") || strings.Contains(buf.String(), "1234567") {
+		t.Errorf("手机号与账号不应出现在日志中: %s", buf.String())
+	}
+}
@@ -0,0 +1,148 @@
+// Package logging 结构化 JSON 日志（log/slog）、请求 ID 与解析 / 生成日志
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HeaderRequestID 请求 ID 请求头 / 响应头
+const HeaderRequestID = "X-Request-ID"
+
+// maxRequestIDLength 客户端传入的请求 ID 最大长度，超出或含非法字符时重新生成
+const maxRequestIDLength = 128
+
+// New 创建 JSON 日志；level 为 debug / info / warn / error，空为 info
+func New(w io.Writer, level string) (*slog.Logger, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: lvl})), nil
+}
+
+// ParseLevel 解析日志级别
+func ParseLevel(level string) (slog.Level, error) {
+	var lvl slog.Level
+	if level == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("日志级别无效: %q", level)
+	}
+	return lvl, nil
+}
+
+type contextKey struct{}
+
+type requestInfo struct {
+	id     string
+	logger *slog.Logger
+}
+
+// WithRequest 将请求 ID 与带 request_id 的日志放入 context
+func WithRequest(ctx context.Context, logger *slog.Logger, requestID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, &requestInfo{
+		id:     requestID,
+		logger: logger.With("request_id", requestID),
+	})
+}
+
+// FromContext 返回请求日志；不在请求中时返回 slog.Default()
+func FromContext(ctx context.Context) *slog.Logger {
+	if info, ok := ctx.Value(contextKey{}).(*requestInfo); ok {
+		return info.logger
+	}
+	return slog.Default()
+}
+
+// RequestID 返回当前请求 ID，不在请求中时为空
+func RequestID(ctx context.Context) string {
+	if info, ok := ctx.Value(contextKey{}).(*requestInfo); ok {
+		return info.id
+	}
+	return ""
+}
+
+// Middleware 请求 ID 与访问日志
+// 沿用客户端传入的 X-Request-ID（合法时），否则生成新 ID；响应头回写 X-Request-ID
+func Middleware(logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		id := NormalizeRequestID(r.Header.Get(HeaderRequestID))
+		w.Header().Set(HeaderRequestID, id)
+
+		ctx := WithRequest(r.Context(), logger, id)
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		level := slog.LevelInfo
+		if rec.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		FromContext(ctx).Log(ctx, level, "http request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"bytes", rec.bytes,
+			"duration_ms", milliseconds(time.Since(start)),
+		)
+	})
+}
+
+// NormalizeRequestID 合法的客户端请求 ID 原样返回，否则生成新 ID
+func NormalizeRequestID(id string) string {
+	if validRequestID(id) {
+		return id
+	}
+	return NewRequestID()
+}
+
+// NewRequestID 生成 16 字节随机十六进制请求 ID
+func NewRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// validRequestID 只接受字母、数字与 "-_.:"，避免日志注入
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	return strings.IndexFunc(id, func(c rune) bool {
+		return !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("-_.:", c))
+	}) < 0
+}
+
+// statusRecorder 记录响应状态码与字节数
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+// milliseconds 耗时（毫秒，保留小数）
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,58 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/logging"
+)
+
+func TestMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	handler := logging.Middleware(slog.New(slog.NewJSONHandler(&buf, nil)), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 处理函数通过 context 取得带请求 ID 的 logger
+		logging.FromContext(r.Context()).Info("handled")
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	call := func(requestID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/x", nil)
+		if requestID != "" {
+			req.Header.Set(logging.HeaderRequestID, requestID)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// 沿用客户端请求 ID
+	if got := call("req-42").Header().Get(logging.HeaderRequestID); got != "req-42" {
+		t.Errorf("应回写请求 ID, got %q", got)
+	}
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("日志应为 JSON: %s", line)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 2 || entries[0]["msg"] != "handled" || entries[0]["request_id"] != "req-42" {
+		t.Fatalf("处理日志错误: %v", entries)
+	}
+	if access := entries[1]; access["msg"] != "http request" || access["request_id"] != "req-42" ||
+		access["status"] != float64(http.StatusTeapot) || access["path"] != "/x" {
+		t.Errorf("访问日志错误: %v", access)
+	}
+
+	// 未传入或非法的请求 ID 重新生成
+	for _, id := range []string{"", "bad id\n", strings.Repeat("a", 200)} {
+		if got := call(id).Header().Get(logging.HeaderRequestID); got == id || len(got) != 32 {
+			t.Errorf("请求 ID %q 应重新生成, got %q", id, got)
+		}
+	}
+}
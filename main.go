@@ -1,34 +1,200 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
-	"net/url"
 	"os"
 	"os/exec"
+	"os/signal"
 	"runtime"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/qinyuanmao/gcash-deeplink/audit"
+	"github.com/qinyuanmao/gcash-deeplink/auth"
+	"github.com/qinyuanmao/gcash-deeplink/config"
 	"github.com/qinyuanmao/gcash-deeplink/generator"
+	"github.com/qinyuanmao/gcash-deeplink/httperr"
+	"github.com/qinyuanmao/gcash-deeplink/i18n"
+	"github.com/qinyuanmao/gcash-deeplink/logging"
+	"github.com/qinyuanmao/gcash-deeplink/metrics"
+	"github.com/qinyuanmao/gcash-deeplink/middleware"
 	"github.com/qinyuanmao/gcash-deeplink/models"
+	"github.com/qinyuanmao/gcash-deeplink/notify"
 	"github.com/qinyuanmao/gcash-deeplink/parser"
+	"github.com/qinyuanmao/gcash-deeplink/store"
+	"github.com/qinyuanmao/gcash-deeplink/webhook"
 )
 
+// appConfig 服务配置（-config 指定的 JSON 文件，未指定时为默认配置）
+var appConfig = config.Default()
+
+// orderStore 已生成 Deep Link 的订单（按 orderId）
+var orderStore = store.NewOrderStore()
+
+// apiKeys API Key 存储（未配置 apiKeysFile 时为 nil，不启用认证）
+var apiKeys *auth.KeyStore
+
+// dispatcher 事件推送（未配置 webhooks 时为 nil）
+var dispatcher *webhook.Dispatcher
+
+// appMetrics 解析 / 生成 / HTTP 指标（/metrics）
+var appMetrics = metrics.NewRegistry()
+
+// auditLog 审计日志（未配置 auditDir 时为 nil）
+var auditLog *audit.Log
+
+// appLogger JSON 结构化日志（按 logLevel 配置）
+var appLogger = slog.Default()
+
 func main() {
+	configPath := flag.String("config", os.Getenv(config.EnvConfigPath), "配置文件路径 (JSON)")
+	addr := flag.String("addr", "", "监听地址（默认使用配置 listenAddr，即 :9000）")
+	tlsCert := flag.String("tls-cert", "", "TLS 证书文件（与 -tls-key 同时指定时启用 HTTPS）")
+	tlsKey := flag.String("tls-key", "", "TLS 私钥文件")
+	noBrowser := flag.Bool("no-browser", false, "启动后不自动打开浏览器")
+	publicDir := flag.String("public-dir", "", "Web 界面目录，覆盖内置页面（默认使用配置 publicDir）")
+	grpcAddr := flag.String("grpc-addr", "", "gRPC 监听地址（默认使用配置 grpcAddr，未配置时不启动 gRPC 服务）")
+	flag.Parse()
+
 	// 显示欢迎信息
 	printBanner()
 
-	// 运行示例
-	if len(os.Args) > 1 && os.Args[1] == "examples" {
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fatal(err)
+	}
+	appConfig = cfg
+	if appLogger, err = logging.New(os.Stderr, cfg.LogLevel); err != nil {
+		fatal(err)
+	}
+	slog.SetDefault(appLogger)
+	models.DefaultBankDirectory().Merge(cfg.Participants)
+	ttl, _ := cfg.OrderTTLDuration() // 已在 config.Load 中校验
+	orderStore.SetTTL(ttl)
+	retention, _ := cfg.OrderRetentionDuration()
+	orderStore.SetRetention(retention)
+	if len(cfg.Webhooks) > 0 {
+		dispatcher = webhook.NewDispatcher(cfg.Webhooks)
+		if cfg.Redaction != nil {
+			dispatcher.SetRedaction(*cfg.Redaction)
+		}
+		if cfg.WebhookDeadLetterFile != "" {
+			if err := dispatcher.PersistDeadLetters(cfg.WebhookDeadLetterFile); err != nil {
+				fatal(err)
+			}
+		}
+		orderStore.SetEventSink(dispatcher)
+	}
+
+	// 子命令
+	switch flag.Arg(0) {
+	case "examples":
 		runExamples()
 		return
+	case "keys":
+		if err := runKeysCommand(flag.Args()[1:]); err != nil {
+			fatal(err)
+		}
+		return
+	case "audit":
+		if err := runAuditCommand(flag.Args()[1:]); err != nil {
+			fatal(err)
+		}
+		return
+	}
+
+	if cfg.APIKeysFile != "" {
+		if apiKeys, err = auth.LoadKeyStore(cfg.APIKeysFile); err != nil {
+			fatal(err)
+		}
+	}
+	setMetricsMerchants()
+
+	if cfg.AuditDir != "" {
+		if auditLog, err = audit.Open(cfg.AuditDir, cfg.AuditMaxBytes); err != nil {
+			fatal(err)
+		}
+		if cfg.Redaction != nil {
+			auditLog.SetRedaction(*cfg.Redaction)
+		}
+		defer auditLog.Close()
+	}
+
+	if *addr != "" {
+		cfg.ListenAddr = *addr
+	}
+	if *grpcAddr != "" {
+		cfg.GRPCAddr = *grpcAddr
+	}
+	if *publicDir != "" {
+		cfg.PublicDir = *publicDir
+	}
+	if *tlsCert != "" || *tlsKey != "" {
+		cfg.TLSCertFile, cfg.TLSKeyFile = *tlsCert, *tlsKey
+	}
+	if err := cfg.Validate(); err != nil {
+		fatal(err)
+	}
+
+	// 启动 HTTP API 服务器，收到 SIGINT / SIGTERM 后优雅退出
+	server := newAPIServer(cfg.ListenAddr)
+	server.certFile, server.keyFile = cfg.TLSCertFile, cfg.TLSKeyFile
+	server.shutdownTimeout, _ = cfg.ShutdownTimeoutDuration()
+	server.openBrowser = !*noBrowser
+
+	printEndpoints(server.URL())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if apiKeys != nil {
+		go reloadKeys(ctx, keysReloadInterval)
+	}
+
+	// gRPC 服务使用独立端口，失败时同时关闭 HTTP 服务
+	var grpcDone chan error
+	if cfg.GRPCAddr != "" {
+		grpcSrv := newGRPCServer(cfg.GRPCAddr)
+		grpcSrv.certFile, grpcSrv.keyFile = cfg.TLSCertFile, cfg.TLSKeyFile
+		grpcSrv.shutdownTimeout = server.shutdownTimeout
+		fmt.Println("🔌 gRPC 服务：" + cfg.GRPCAddr + "（gcashdeeplink.v1.DeepLinkService，支持健康检查与反射）")
+		grpcDone = make(chan error, 1)
+		go func() {
+			err := grpcSrv.Run(ctx)
+			if err != nil {
+				stop()
+			}
+			grpcDone <- err
+		}()
 	}
 
-	// 启动 HTTP API 服务器
-	startHTTPServer()
+	if err := server.Run(ctx); err != nil {
+		fatal(err)
+	}
+	if grpcDone != nil {
+		if err := <-grpcDone; err != nil {
+			fatal(err)
+		}
+	}
+	if dispatcher != nil {
+		if err := dispatcher.Close(); err != nil {
+			slog.Error("保存 webhook 死信失败", "error", err)
+		}
+	}
+	slog.Info("HTTP 服务已关闭")
+}
+
+// fatal 记录错误日志并退出
+func fatal(err error) {
+	slog.Error(err.Error())
+	os.Exit(1)
 }
 
 func printBanner() {
@@ -92,7 +258,9 @@ func example2() {
 
 	qrCode := "00020101021228790011ph.ppmi.p2m0111PAEYPHM2XXX0324VkHUE2Fz8Ee2YxnTVPX34TZs0410030300288605030105204739953036085406100.005802PH5916NEXA ONLINE SHOP6013General Trias62430012ph.ppmi.qrph0306wWMBdH05062110000803***88440012ph.ppmi.qrph0124VkHUE2Fz8Ee2YxnTVPX34TZs63041C3C"
 
+	// 回调 URL 的主机必须在允许列表中
 	g := generator.NewDeepLinkGenerator()
+	g.SetURLPolicy(models.URLPolicy{Hosts: []string{"myshop.com"}})
 
 	options := &models.DeepLinkOptions{
 		PaymentType: models.PaymentTypeDynamic,
@@ -123,29 +291,78 @@ func example3() {
 	p := parser.NewEMVCoParser()
 	data, _ := p.Parse(qrCode)
 
-	g := generator.NewDeepLinkGenerator()
-	strategies := g.GenerateMultiple(data)
+	g := newGenerator(context.Background())
+	strategies, errs := g.GenerateMultiple(data)
 
 	for name, link := range strategies {
 		fmt.Printf("策略: %s\n", name)
 		fmt.Printf("链接: %s\n\n", link)
 	}
+	for name, err := range errs {
+		fmt.Printf("策略: %s\n", name)
+		fmt.Printf("失败: %v\n\n", err)
+	}
 }
 
-// HTTP API 服务器
-func startHTTPServer() {
-	// 静态文件服务器
-	fs := http.FileServer(http.Dir("./public"))
-	http.Handle("/", fs)
+// setMetricsMerchants 以配置与 API Key 中的商户作为指标 merchant 标签的取值范围
+func setMetricsMerchants() {
+	merchants := appConfig.Merchants()
+	if apiKeys != nil {
+		merchants = append(merchants, apiKeys.Merchants()...)
+	}
+	appMetrics.SetMerchants(merchants)
+}
 
-	// API 端点
-	http.HandleFunc("/api/parse", handleParse)
-	http.HandleFunc("/api/generate", handleGenerate)
-	http.HandleFunc("/api/validate", handleValidate)
-	http.HandleFunc("/health", handleHealth)
+// newGenerator 按配置创建生成器（策略集与参数布局），生成结果记录到 ctx 的请求日志
+func newGenerator(ctx context.Context) *generator.DeepLinkGenerator {
+	g := generator.NewDeepLinkGenerator()
+	g.SetStrategies(appConfig.Strategies)
+	// 参数布局已在 config.Load 中校验
+	_ = g.SetProfiles(appConfig.Profiles, appConfig.DefaultProfile)
+	if appConfig.AmountPolicy != nil {
+		g.SetAmountPolicy(*appConfig.AmountPolicy)
+	}
+	if appConfig.MCCPolicy != nil {
+		g.SetMCCPolicy(*appConfig.MCCPolicy)
+	}
+	if appConfig.URLPolicy != nil {
+		g.SetURLPolicy(*appConfig.URLPolicy)
+	}
+	g.SetReturnURL(appConfig.PublicBaseURL)
+	if dispatcher != nil {
+		g.SetEventSink(dispatcher)
+	}
+	g.SetMetrics(observer(ctx))
+	// 带订单号的链接登记到订单存储，供支付通知匹配
+	g.SetOrderRegistry(orderStore)
+	if auditLog != nil {
+		var actor string
+		if key := auth.FromContext(ctx); key != nil {
+			actor = key.ID
+		}
+		g.SetAuditSink(audit.Attribute(auditLog, actor, logging.RequestID(ctx)))
+	}
+	return g
+}
+
+// newParser 创建上报指标与日志的解析器
+func newParser(ctx context.Context) *parser.EMVCoParser {
+	p := parser.NewEMVCoParser()
+	p.SetMetrics(observer(ctx))
+	return p
+}
 
-	serverURL := "http://localhost:9000"
+// observer 解析 / 生成的指标与日志回调（日志带 ctx 中的请求 ID，按 redaction 脱敏）
+func observer(ctx context.Context) models.MetricsHook {
+	hook := logging.Hook{Logger: logging.FromContext(ctx)}
+	if appConfig.Redaction != nil {
+		hook.Redact = *appConfig.Redaction
+	}
+	return models.MetricsHooks{appMetrics, hook}
+}
 
+// printEndpoints 打印服务地址与可用端点
+func printEndpoints(serverURL string) {
 	fmt.Println("🚀 HTTP API 服务启动")
 	fmt.Println("📍 地址：" + serverURL)
 	fmt.Println("🌐 Web 界面：" + serverURL)
@@ -153,151 +370,335 @@ func startHTTPServer() {
 	fmt.Println("  GET    /               - Web 界面")
 	fmt.Println("  POST   /api/parse      - 解析 EMVCo QR Code")
 	fmt.Println("  POST   /api/generate   - 生成 GCash Deep Link")
+	fmt.Println("  GET    /api/generate/strategies - 查看已配置的生成策略")
+	fmt.Println("  POST   /api/generate/strategies - 按策略集批量生成 Deep Link")
+	fmt.Println("  GET    /api/profiles   - 查看可用的参数布局")
+	fmt.Println("  GET    /api/banks      - 查看 QR Ph 机构目录")
 	fmt.Println("  POST   /api/validate   - 验证 QR Code")
+	fmt.Println("  POST   /api/gcash/notify - 接收 GCash 支付通知")
+	fmt.Println("  GET    /api/orders/{orderId} - 查询订单状态")
+	fmt.Println("  GET    /pay/{orderId}  - 打开支付链接（跳转到 Deep Link）")
+	fmt.Println("  GET    /return/{orderId} - 支付完成后跳转到商户页面（替换 {status}）")
+	fmt.Println("  GET    /api/webhooks/dead-letters - 查看投递失败的事件")
+	fmt.Println("  POST   /api/webhooks/dead-letters/{id}/replay - 重新投递事件")
+	fmt.Println("  *      /v1/...         - 同上 /api 接口的版本化路径（类型化响应、统一错误码）")
+	fmt.Println("  GET    /v1/openapi.json - OpenAPI 3 文档")
 	fmt.Println("  GET    /health         - 健康检查")
+	fmt.Println("  GET    /livez          - 存活探测")
+	fmt.Println("  GET    /readyz         - 就绪探测（关闭过程中返回 503）")
+	fmt.Println("  GET    /metrics        - Prometheus 指标")
 	fmt.Println()
-
-	// 自动打开浏览器
-	go openBrowser(serverURL)
-
-	log.Fatal(http.ListenAndServe(":9000", enableCORS(http.DefaultServeMux)))
+	if apiKeys == nil {
+		fmt.Println("⚠️  未配置 apiKeysFile，API 未启用认证")
+	}
 }
 
 // API 处理函数
+// 同一处理函数同时服务 /api 与 /v1 路径：/v1 成功时返回 models 中的响应类型本身，
+// 错误为 {"error": {"code", "message"}}；/api 保持原有的 {"success": ...} 格式
+
 func handleParse(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "只支持 POST 请求", http.StatusMethodNotAllowed)
+		httperr.MethodNotAllowed(w, r, http.MethodPost)
 		return
 	}
 
-	var req struct {
-		QRCode string `json:"qrCode"`
+	var req models.ParseRequest
+	if !decodeJSON(w, r, &req) {
+		return
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondJSON(w, http.StatusBadRequest, map[string]interface{}{
-			"success": false,
-			"error":   "无效的 JSON",
-		})
+	input := decodeQRCode(w, r, req.QRCode, req.Encoding)
+	if input == nil {
 		return
 	}
 
-	// URL 解码 QR Code 数据,将可能的 + 转换为空格
-	qrCode, err := url.QueryUnescape(req.QRCode)
+	p := newParser(r.Context())
+	data, err := p.Parse(input.QRCode)
 	if err != nil {
-		// 如果解码失败,使用原始数据
-		qrCode = req.QRCode
+		httperr.Error(w, r, http.StatusBadRequest, withCode(err, models.CodeParseFailed))
+		return
 	}
 
-	p := parser.NewEMVCoParser()
-	data, err := p.Parse(qrCode)
+	respondOK(w, r, http.StatusOK, models.ParseResponse{Data: data, Input: input})
+}
+
+func handleGenerate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httperr.MethodNotAllowed(w, r, http.MethodPost)
+		return
+	}
+
+	var req models.GenerateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.QRCode == "" {
+		httperr.Write(w, r, http.StatusBadRequest, models.CodeQREmpty, "qrCode 不能为空")
+		return
+	}
+
+	if err := auth.CheckMerchant(r.Context(), req.MerchantID); err != nil {
+		httperr.Write(w, r, http.StatusForbidden, models.CodeForbidden, err.Error())
+		return
+	}
+
+	input := decodeQRCode(w, r, req.QRCode, req.Encoding)
+	if input == nil {
+		return
+	}
+
+	g := newGenerator(r.Context())
+	result, err := g.GenerateWithValidation(input.QRCode, req.Options())
 	if err != nil {
-		respondJSON(w, http.StatusBadRequest, map[string]interface{}{
-			"success": false,
-			"error":   err.Error(),
-		})
+		httperr.Error(w, r, generateStatus(err), withCode(err, models.CodeGenerateFailed))
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"success": true,
-		"data":    data,
-	})
+	result.Input = input
+	respondOK(w, r, http.StatusOK, result)
 }
 
-func handleGenerate(w http.ResponseWriter, r *http.Request) {
+// handleGenerateStrategies GET 返回已配置的策略集；POST 按策略集批量生成
+// 请求可通过 strategies 字段临时指定策略，未指定时使用配置中的策略
+func handleGenerateStrategies(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		respondOK(w, r, http.StatusOK, models.StrategiesResponse{Strategies: appConfig.Strategies})
+		return
+	}
 	if r.Method != http.MethodPost {
-		http.Error(w, "只支持 POST 请求", http.StatusMethodNotAllowed)
+		httperr.MethodNotAllowed(w, r, http.MethodGet, http.MethodPost)
 		return
 	}
 
-	var req struct {
-		QRCode       string `json:"qrCode"`
-		OrderID      string `json:"orderId,omitempty"`
-		MerchantID   string `json:"merchantId,omitempty"`
-		MerchantName string `json:"merchantName,omitempty"`
-		RedirectURL  string `json:"redirectUrl,omitempty"`
-		NotifyURL    string `json:"notifyUrl,omitempty"`
-		PaymentType  string `json:"paymentType,omitempty"`
+	var req models.GenerateStrategiesRequest
+	if !decodeJSON(w, r, &req) {
+		return
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondJSON(w, http.StatusBadRequest, map[string]interface{}{
-			"success": false,
-			"error":   "无效的 JSON",
-		})
+	if req.QRCode == "" {
+		httperr.Write(w, r, http.StatusBadRequest, models.CodeQREmpty, "qrCode 不能为空")
 		return
 	}
 
-	if req.QRCode == "" {
-		respondJSON(w, http.StatusBadRequest, map[string]interface{}{
-			"success": false,
-			"error":   "qrCode 不能为空",
-		})
+	if err := config.ValidateStrategies(req.Strategies); err != nil {
+		httperr.Write(w, r, http.StatusBadRequest, models.CodeInvalidStrategies, err.Error())
+		return
+	}
+
+	// 策略可覆盖 merchantId，逐一校验
+	strategies := req.Strategies
+	if len(strategies) == 0 {
+		strategies = appConfig.Strategies
+	}
+	for _, s := range strategies {
+		merchantID := req.MerchantID
+		if s.Options.MerchantID != "" {
+			merchantID = s.Options.MerchantID
+		}
+		if err := auth.CheckMerchant(r.Context(), merchantID); err != nil {
+			httperr.Write(w, r, http.StatusForbidden, models.CodeForbidden, fmt.Sprintf("策略 %s: %v", s.Name, err))
+			return
+		}
+	}
+
+	input := decodeQRCode(w, r, req.QRCode, req.Encoding)
+	if input == nil {
 		return
 	}
 
-	// URL 解码 QR Code 数据,将可能的 + 转换为空格
-	qrCode, err := url.QueryUnescape(req.QRCode)
+	p := newParser(r.Context())
+	data, err := p.Parse(input.QRCode)
 	if err != nil {
-		// 如果解码失败,使用原始数据
-		qrCode = req.QRCode
+		httperr.Error(w, r, http.StatusBadRequest, withCode(fmt.Errorf("解析失败: %w", err), models.CodeParseFailed))
+		return
 	}
 
-	options := &models.DeepLinkOptions{
-		OrderID:      req.OrderID,
-		MerchantID:   req.MerchantID,
-		MerchantName: req.MerchantName,
-		RedirectURL:  req.RedirectURL,
-		NotifyURL:    req.NotifyURL,
+	g := newGenerator(r.Context())
+	results := g.GenerateStrategies(data, req.Options(), req.Strategies)
+	if lang := httperr.Lang(r); lang != i18n.DefaultLang {
+		for i := range results {
+			if msg, ok := i18n.Message(lang, results[i].ErrorCode, nil); ok {
+				results[i].Error = msg
+			}
+		}
 	}
 
-	if req.PaymentType != "" {
-		options.PaymentType = models.PaymentType(req.PaymentType)
+	respondOK(w, r, http.StatusOK, models.GenerateStrategiesResponse{ParsedData: data, Input: input, Results: results})
+}
+
+// handleProfiles 返回可用的参数布局及默认布局
+func handleProfiles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httperr.MethodNotAllowed(w, r, http.MethodGet)
+		return
 	}
 
-	g := generator.NewDeepLinkGenerator()
-	result, err := g.GenerateWithValidation(qrCode, options)
-	if err != nil {
-		respondJSON(w, http.StatusBadRequest, map[string]interface{}{
-			"success": false,
-			"error":   err.Error(),
-		})
+	respondOK(w, r, http.StatusOK, models.ProfilesResponse{
+		DefaultProfile: appConfig.DefaultProfile,
+		Profiles:       appConfig.Profiles,
+	})
+}
+
+// handleBanks 返回 QR Ph 机构目录；?bic= 查询单个机构
+func handleBanks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httperr.MethodNotAllowed(w, r, http.MethodGet)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, result)
+	directory := models.DefaultBankDirectory()
+	if bic := r.URL.Query().Get("bic"); bic != "" {
+		bank, ok := directory.Lookup(bic)
+		if !ok {
+			httperr.Error(w, r, http.StatusNotFound, &models.Error{
+				Code:    models.CodeUnknownBank,
+				Message: "未知的 BIC: " + bic,
+				Params:  models.Params{"bic": bic},
+			})
+			return
+		}
+		respondOK(w, r, http.StatusOK, models.BankResponse{Bank: bank})
+		return
+	}
+
+	respondOK(w, r, http.StatusOK, models.BanksResponse{Banks: directory.All()})
 }
 
+// handleValidate 验证 QR Code；两个版本均直接返回 ValidationResult
 func handleValidate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "只支持 POST 请求", http.StatusMethodNotAllowed)
+		httperr.MethodNotAllowed(w, r, http.MethodPost)
+		return
+	}
+
+	var req models.ValidateRequest
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	var req struct {
-		QRCode string `json:"qrCode"`
+	input := decodeQRCode(w, r, req.QRCode, req.Encoding)
+	if input == nil {
+		return
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondJSON(w, http.StatusBadRequest, map[string]interface{}{
-			"success": false,
-			"error":   "无效的 JSON",
+	p := newParser(r.Context())
+	validation := p.Validate(input.QRCode)
+	validation.Input = input
+	i18n.LocalizeValidation(validation, httperr.Lang(r))
+
+	respondJSON(w, http.StatusOK, validation)
+}
+
+// notifyHandler GCash 支付通知接收器；未配置 notifySecret 时拒绝所有通知
+func notifyHandler() http.Handler {
+	if appConfig.NotifySecret == "" {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			httperr.Write(w, r, http.StatusServiceUnavailable, models.CodeUnavailable, "未配置 notifySecret，无法校验支付通知")
 		})
+	}
+
+	verifier := notify.NewHMACVerifier(appConfig.NotifySecret)
+	if appConfig.NotifySignatureHeader != "" {
+		verifier.Header = appConfig.NotifySignatureHeader
+	}
+	return notify.NewReceiver(verifier, orderStore)
+}
+
+// handleOrder 查询订单状态 GET /api/orders/{orderId}、/v1/orders/{orderId}
+func handleOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httperr.MethodNotAllowed(w, r, http.MethodGet)
 		return
 	}
 
-	// URL 解码 QR Code 数据,将可能的 + 转换为空格
-	qrCode, err := url.QueryUnescape(req.QRCode)
+	_, orderID, _ := strings.Cut(r.URL.Path, "/orders/")
+	order, err := orderStore.Get(orderID)
+	if err == nil && auth.CheckMerchant(r.Context(), order.MerchantID) != nil {
+		// 不向无权限的 Key 暴露订单是否存在
+		err = models.ErrOrderNotFound.Errorf(models.Params{"orderId": order.OrderID}, "%s", order.OrderID)
+	}
 	if err != nil {
-		// 如果解码失败,使用原始数据
-		qrCode = req.QRCode
+		httperr.Error(w, r, http.StatusNotFound, err)
+		return
 	}
 
-	p := parser.NewEMVCoParser()
-	validation := p.Validate(qrCode)
+	respondOK(w, r, http.StatusOK, models.OrderResponse{Order: order})
+}
 
-	respondJSON(w, http.StatusOK, validation)
+// handlePay 打开支付链接 GET /pay/{orderId}：记录 link_opened 并跳转到 Deep Link
+func handlePay(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httperr.MethodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+
+	order, err := orderStore.Open(strings.TrimPrefix(r.URL.Path, "/pay/"))
+	if err != nil {
+		status := http.StatusConflict
+		switch {
+		case errors.Is(err, models.ErrOrderNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, models.ErrOrderExpired):
+			status = http.StatusGone
+		}
+		httperr.Error(w, r, status, err)
+		return
+	}
+
+	http.Redirect(w, r, order.DeepLink, http.StatusFound)
+}
+
+// handleDeadLetters GET /api/webhooks/dead-letters 返回死信列表；POST .../{id}/replay 重新投递（/v1 路径相同）
+func handleDeadLetters(w http.ResponseWriter, r *http.Request) {
+	if dispatcher == nil {
+		httperr.Write(w, r, http.StatusNotFound, models.CodeNotFound, "未配置 webhooks")
+		return
+	}
+
+	_, rest, _ := strings.Cut(r.URL.Path, "/webhooks/dead-letters")
+	rest = strings.Trim(rest, "/")
+	id, replay := strings.CutSuffix(rest, "/replay")
+	switch {
+	case rest == "":
+		if r.Method != http.MethodGet {
+			httperr.MethodNotAllowed(w, r, http.MethodGet)
+			return
+		}
+		respondOK(w, r, http.StatusOK, webhook.DeadLettersResponse{DeadLetters: dispatcher.DeadLetters()})
+	case replay && id != "" && !strings.Contains(id, "/"):
+		if r.Method != http.MethodPost {
+			httperr.MethodNotAllowed(w, r, http.MethodPost)
+			return
+		}
+		if err := dispatcher.Replay(id); errors.Is(err, webhook.ErrClosed) {
+			httperr.Write(w, r, http.StatusServiceUnavailable, models.CodeUnavailable, err.Error())
+			return
+		} else if err != nil {
+			httperr.Write(w, r, http.StatusNotFound, models.CodeNotFound, err.Error())
+			return
+		}
+		respondOK(w, r, http.StatusAccepted, webhook.ReplayResponse{ID: id})
+	default:
+		httperr.Write(w, r, http.StatusNotFound, models.CodeNotFound, "不存在的死信接口")
+	}
+}
+
+// handleReturn 用户支付后从 GCash 返回 GET /return/{orderId}：按订单当前状态展开 {status} 并跳转到商户页面
+func handleReturn(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httperr.MethodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+
+	order, err := orderStore.Get(strings.TrimPrefix(r.URL.Path, "/return/"))
+	if err != nil || order.RedirectURL == "" {
+		httperr.Write(w, r, http.StatusNotFound, models.CodeOrderNotFound, "订单不存在或未设置跳转地址")
+		return
+	}
+
+	http.Redirect(w, r, generator.ExpandStatus(order.RedirectURL, order.Status), http.StatusFound)
 }
 
 func handleHealth(w http.ResponseWriter, r *http.Request) {
@@ -309,17 +710,105 @@ func handleHealth(w http.ResponseWriter, r *http.Request) {
 }
 
 // 辅助函数
+
+// decodeJSON 解析请求体，失败时写入 400（请求体超限时 413）并返回 false
+// 拒绝未知字段，避免拼写错误的选项被静默忽略
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	err := dec.Decode(v)
+	if err == nil {
+		return true
+	}
+	if middleware.IsTooLarge(err) {
+		middleware.RequestTooLarge(w, r, appConfig.Limits.MaxBodyBytes)
+		return false
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		httperr.Error(w, r, http.StatusBadRequest, &models.Error{
+			Code:    models.CodeInvalidField,
+			Message: fmt.Sprintf("字段 %s 类型错误: 应为 %s", typeErr.Field, typeErr.Type),
+			Params:  models.Params{"field": typeErr.Field, "type": typeErr.Type.String()},
+		})
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		httperr.Error(w, r, http.StatusBadRequest, &models.Error{
+			Code:    models.CodeUnknownField,
+			Message: fmt.Sprintf("未知字段: %s", field),
+			Params:  models.Params{"field": field},
+		})
+	default:
+		httperr.Write(w, r, http.StatusBadRequest, models.CodeInvalidJSON, "无效的 JSON")
+	}
+	return false
+}
+
+// decodeQRCode 按请求的 encoding 还原 qrCode（默认 raw，不做 URL 解码），失败时写入 400 并返回 nil
+func decodeQRCode(w http.ResponseWriter, r *http.Request, qrCode string, encoding models.QREncoding) *models.ParsedInput {
+	input, err := models.DecodeInput(qrCode, encoding)
+	if err != nil {
+		httperr.Error(w, r, http.StatusBadRequest, err)
+		return nil
+	}
+	return input
+}
+
+// withCode 为未携带错误码的错误补充 code，使客户端始终能按错误码判断
+func withCode(err error, code string) error {
+	var e *models.Error
+	if errors.As(err, &e) {
+		return err
+	}
+	return &models.Error{Code: code, Message: err.Error(), Err: err}
+}
+
+// generateStatus 生成错误的 HTTP 状态码：订单已进入支付流程为 409，审计记录写入失败为 500，其余为 400
+func generateStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrOrderExists):
+		return http.StatusConflict
+	case errors.Is(err, models.ErrAuditFailed):
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// respondOK 写入成功响应：/v1 为响应类型本身，/api 额外带 "success": true
+func respondOK(w http.ResponseWriter, r *http.Request, status int, resp interface{}) {
+	if httperr.IsV1(r) {
+		respondJSON(w, status, resp)
+		return
+	}
+
+	fields := map[string]json.RawMessage{}
+	b, err := json.Marshal(resp)
+	if err == nil {
+		err = json.Unmarshal(b, &fields)
+	}
+	if err != nil {
+		httperr.Write(w, r, http.StatusInternalServerError, models.CodeInternal, err.Error())
+		return
+	}
+	fields["success"] = json.RawMessage("true")
+	respondJSON(w, status, fields)
+}
+
 func respondJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	httperr.JSON(w, status, data)
 }
 
+// enableCORS 跨域: 仅允许 corsOrigins 中的来源；未配置时仅在未启用认证时允许 "*"
 func enableCORS(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
+		if origin := allowedOrigin(r.Header.Get("Origin")); origin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Add("Vary", "Origin")
+		}
 		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, "+auth.HeaderAPIKey)
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -330,6 +819,22 @@ func enableCORS(h http.Handler) http.Handler {
 	})
 }
 
+// allowedOrigin 返回允许的 Access-Control-Allow-Origin 值，不允许时为空
+func allowedOrigin(origin string) string {
+	if len(appConfig.CORSOrigins) == 0 {
+		if apiKeys == nil {
+			return "*"
+		}
+		return ""
+	}
+	for _, o := range appConfig.CORSOrigins {
+		if o == "*" || o == origin {
+			return o
+		}
+	}
+	return ""
+}
+
 // 自动打开浏览器
 func openBrowser(url string) {
 	// 等待服务器启动
@@ -1,12 +1,46 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/qinyuanmao/gcash-deeplink/auth"
+	"github.com/qinyuanmao/gcash-deeplink/config"
 	"github.com/qinyuanmao/gcash-deeplink/generator"
+	"github.com/qinyuanmao/gcash-deeplink/i18n"
+	"github.com/qinyuanmao/gcash-deeplink/logging"
+	"github.com/qinyuanmao/gcash-deeplink/middleware"
 	"github.com/qinyuanmao/gcash-deeplink/models"
 	"github.com/qinyuanmao/gcash-deeplink/parser"
+	deeplinkv1 "github.com/qinyuanmao/gcash-deeplink/proto/deeplink/v1"
+	"github.com/qinyuanmao/gcash-deeplink/webhook"
 )
 
 func TestParseEMVCoQR(t *testing.T) {
@@ -108,7 +142,7 @@ func TestGenerateMultipleStrategies(t *testing.T) {
 	}
 
 	g := generator.NewDeepLinkGenerator()
-	strategies := g.GenerateMultiple(data)
+	strategies, errs := g.GenerateMultiple(data)
 
 	expectedStrategies := []string{"minimal", "dynamic", "with_callback"}
 	for _, strategy := range expectedStrategies {
@@ -116,6 +150,9 @@ func TestGenerateMultipleStrategies(t *testing.T) {
 			t.Errorf("策略 %s 应该存在且不为空", strategy)
 		}
 	}
+	if len(errs) != 0 {
+		t.Errorf("不应有失败的策略: %v", errs)
+	}
 }
 
 func TestNewQRFormat(t *testing.T) {
@@ -139,11 +176,11 @@ func TestNewQRFormat(t *testing.T) {
 	// NewQRFormat=true 交换后:
 	// shopId 应为订单号(原 Tag 62-05): 2165332951297191950
 	// acqInfo 应为 UID(原 Tag 28-03): 2082899083478722304
-	if result.Options.ShopID != "2165332951297191950" {
-		t.Errorf("shopId 错误: got %s, want 2165332951297191950", result.Options.ShopID)
+	if result.Resolved.ShopID != "2165332951297191950" {
+		t.Errorf("shopId 错误: got %s, want 2165332951297191950", result.Resolved.ShopID)
 	}
-	if result.ParsedData.AcqInfo != "2082899083478722304" {
-		t.Errorf("acqInfo 错误: got %s, want 2082899083478722304", result.ParsedData.AcqInfo)
+	if result.Resolved.AcqInfo != "2082899083478722304" {
+		t.Errorf("acqInfo 错误: got %s, want 2082899083478722304", result.Resolved.AcqInfo)
 	}
 }
 
@@ -239,8 +276,8 @@ func TestNewQRFormatEmptyAcqInfo(t *testing.T) {
 	}
 
 	// ShopID 不应被清空
-	if result.Options.ShopID != "SHOP123" {
-		t.Errorf("ShopID 被清空: got %q, want SHOP123", result.Options.ShopID)
+	if result.Resolved.ShopID != "SHOP123" {
+		t.Errorf("ShopID 被清空: got %q, want SHOP123", result.Resolved.ShopID)
 	}
 
 	// param5 应包含 ShopID
@@ -283,3 +320,1075 @@ func BenchmarkGenerateDeepLink(b *testing.B) {
 		_, _ = g.GenerateWithValidation(qrCode, options)
 	}
 }
+
+func TestHandleGenerateStrategies(t *testing.T) {
+	body := `{
+		"qrCode": "00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275",
+		"orderId": "ORDER-QA",
+		"strategies": [{"name": "qa", "options": {"paymentType": "001", "bizNo": "BIZ-1"}}]
+	}`
+
+	rec := httptest.NewRecorder()
+	handleGenerateStrategies(rec, httptest.NewRequest(http.MethodPost, "/api/generate/strategies", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("状态码错误: %d, body: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp struct {
+		Success bool                    `json:"success"`
+		Results []models.StrategyResult `json:"results"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("响应解析失败: %v", err)
+	}
+	if !resp.Success || len(resp.Results) != 1 || resp.Results[0].Name != "qa" {
+		t.Fatalf("响应错误: %s", rec.Body.String())
+	}
+	if !containsParam(resp.Results[0].DeepLink, "bizNo", "BIZ-1") || !containsParam(resp.Results[0].DeepLink, "orderId", "ORDER-QA") {
+		t.Errorf("策略未叠加到请求选项上, deepLink: %s", resp.Results[0].DeepLink)
+	}
+
+	// 策略名称重复应被拒绝
+	dup := `{"qrCode": "000201", "strategies": [{"name": "x"}, {"name": "x"}]}`
+	rec = httptest.NewRecorder()
+	handleGenerateStrategies(rec, httptest.NewRequest(http.MethodPost, "/api/generate/strategies", strings.NewReader(dup)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("重复策略应返回 400, got %d", rec.Code)
+	}
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	body := `{"qrCode": "00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275", "orderId": "ORDER-LIFE"}`
+	rec := httptest.NewRecorder()
+	handleGenerate(rec, httptest.NewRequest(http.MethodPost, "/api/generate", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("生成失败: %d %s", rec.Code, rec.Body.String())
+	}
+
+	// 打开链接: 跳转到 Deep Link 并记录 link_opened
+	rec = httptest.NewRecorder()
+	handlePay(rec, httptest.NewRequest(http.MethodGet, "/pay/ORDER-LIFE", nil))
+	if rec.Code != http.StatusFound || !strings.HasPrefix(rec.Header().Get("Location"), generator.GCashBaseURL) {
+		t.Fatalf("应跳转到 Deep Link: %d %s", rec.Code, rec.Header().Get("Location"))
+	}
+
+	rec = httptest.NewRecorder()
+	handleOrder(rec, httptest.NewRequest(http.MethodGet, "/api/orders/ORDER-LIFE", nil))
+	var resp struct {
+		Order models.Order `json:"order"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("查询订单失败: %d %s", rec.Code, rec.Body.String())
+	}
+	if resp.Order.Status != models.OrderLinkOpened || resp.Order.Timestamps[models.OrderLinkOpened].IsZero() {
+		t.Errorf("订单应为 link_opened: %+v", resp.Order)
+	}
+
+	rec = httptest.NewRecorder()
+	handleOrder(rec, httptest.NewRequest(http.MethodGet, "/api/orders/ORDER-NONE", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("未知订单应返回 404, got %d", rec.Code)
+	}
+}
+
+func TestDeadLetterRoutes(t *testing.T) {
+	var accept atomic.Bool
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !accept.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer subscriber.Close()
+	d := webhook.NewDispatcher([]models.WebhookSubscription{{URL: subscriber.URL, Secret: "whsec"}})
+	d.MaxAttempts = 1
+	d.Publish(models.Event{Type: models.EventPaymentFailed, MerchantID: "M-1", OrderID: "ORDER-D1"})
+	d.Wait()
+	dispatcher = d
+	defer func() { dispatcher = nil }()
+
+	s := newAPIServer("127.0.0.1:0")
+	call := func(method, path string) (*httptest.ResponseRecorder, map[string]json.RawMessage) {
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		var resp map[string]json.RawMessage
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		return rec, resp
+	}
+
+	// /v1 为类型化响应，/api 保持 success 字段
+	rec, resp := call(http.MethodGet, "/v1/webhooks/dead-letters")
+	var list webhook.DeadLettersResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); rec.Code != http.StatusOK || err != nil || len(list.DeadLetters) != 1 || resp["success"] != nil {
+		t.Fatalf("/v1 死信列表: %d %s", rec.Code, rec.Body.String())
+	}
+	if _, resp = call(http.MethodGet, "/api/webhooks/dead-letters"); string(resp["success"]) != "true" || resp["deadLetters"] == nil {
+		t.Errorf("/api 死信列表响应格式变化: %v", resp)
+	}
+
+	id := list.DeadLetters[0].ID
+	if rec, resp = call(http.MethodGet, "/v1/webhooks/dead-letters/"+id+"/replay"); rec.Code != http.StatusMethodNotAllowed || resp["error"] == nil {
+		t.Errorf("GET 重放应返回 405: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec, _ = call(http.MethodPost, "/v1/webhooks/dead-letters/nope/replay"); rec.Code != http.StatusNotFound {
+		t.Errorf("未知死信应返回 404, got %d", rec.Code)
+	}
+	accept.Store(true)
+	if rec, _ = call(http.MethodPost, "/v1/webhooks/dead-letters/"+id+"/replay"); rec.Code != http.StatusAccepted || !strings.Contains(rec.Body.String(), id) {
+		t.Errorf("重放失败: %d %s", rec.Code, rec.Body.String())
+	}
+	d.Wait()
+	if len(d.DeadLetters()) != 0 {
+		t.Errorf("重放成功后死信应为空: %+v", d.DeadLetters())
+	}
+}
+
+func TestReturnRedirect(t *testing.T) {
+	// {status}: 链接跳转到本服务 /return/{orderId}，返回时按订单状态展开
+	g := generator.NewDeepLinkGenerator()
+	g.SetURLPolicy(models.URLPolicy{Hosts: []string{"*.myshop.com"}})
+	g.SetReturnURL("https://gateway.example/")
+	result, err := g.GenerateWithValidation("00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275", &models.DeepLinkOptions{OrderID: "ORDER-RET", RedirectURL: "https://pay.myshop.com/result?status={status}"})
+	if err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	if _, err := orderStore.Save(result); err != nil {
+		t.Fatalf("保存订单失败: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	handleReturn(rec, httptest.NewRequest(http.MethodGet, "/return/ORDER-RET", nil))
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != "https://pay.myshop.com/result?status=created" {
+		t.Errorf("返回跳转错误: %d %s", rec.Code, rec.Header().Get("Location"))
+	}
+}
+
+func TestExampleConfigCallbackURL(t *testing.T) {
+	// 仓库附带的示例配置应能直接生成带回调 URL 的链接
+	cfg, err := config.Load("config.example.json")
+	if err != nil {
+		t.Fatalf("加载示例配置失败: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("示例配置校验失败: %v", err)
+	}
+	saved := appConfig
+	appConfig = cfg
+	defer func() { appConfig = saved }()
+
+	body := `{"qrCode": "00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275", "orderId": "ORDER-EXAMPLE", "redirectUrl": "https://myshop.com/success", "notifyUrl": "https://myshop.com/webhook"}`
+	rec := httptest.NewRecorder()
+	handleGenerate(rec, httptest.NewRequest(http.MethodPost, "/api/generate", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("示例配置下生成失败: %d %s", rec.Code, rec.Body.String())
+	}
+	var resp models.GenerateResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !containsParam(resp.DeepLink, "notifyUrl", "https://myshop.com/webhook") {
+		t.Errorf("deepLink 应包含 notifyUrl: %s", resp.DeepLink)
+	}
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	keys, err := auth.LoadKeyStore(filepath.Join(t.TempDir(), "keys.json"))
+	if err != nil {
+		t.Fatalf("加载密钥文件失败: %v", err)
+	}
+	shopA, _, err := keys.Create("shop-a", []auth.Scope{auth.ScopeGenerate}, []string{"M-A"})
+	if err != nil {
+		t.Fatalf("创建 Key 失败: %v", err)
+	}
+	parseOnly, _, _ := keys.Create("parser", []auth.Scope{auth.ScopeParse}, nil)
+
+	handler := auth.Require(keys, auth.ScopeGenerate, http.HandlerFunc(handleGenerate))
+	call := func(token, merchantID string) int {
+		body := `{"qrCode": "00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275", "merchantId": "` + merchantID + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/generate", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		merchantID string
+		want       int
+	}{
+		{"缺少 Key", "", "M-A", http.StatusUnauthorized},
+		{"无效 Key", "gdl_bogus", "M-A", http.StatusUnauthorized},
+		{"scope 不足", parseOnly, "M-A", http.StatusForbidden},
+		{"其他商户", shopA, "M-B", http.StatusForbidden},
+		{"未指定商户", shopA, "", http.StatusForbidden},
+		{"允许的商户", shopA, "M-A", http.StatusOK},
+	}
+	for _, tt := range tests {
+		if got := call(tt.token, tt.merchantID); got != tt.want {
+			t.Errorf("%s: 状态码 %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	// 参数布局与机构目录需要 parse scope
+	apiKeys = keys
+	s := newAPIServer("127.0.0.1:0")
+	apiKeys = nil
+	for _, path := range []string{"/api/profiles", "/v1/profiles", "/api/banks", "/v1/banks"} {
+		for token, want := range map[string]int{"": http.StatusUnauthorized, shopA: http.StatusForbidden, parseOnly: http.StatusOK} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			if token != "" {
+				req.Header.Set("X-API-Key", token)
+			}
+			rec := httptest.NewRecorder()
+			s.mux.ServeHTTP(rec, req)
+			if rec.Code != want {
+				t.Errorf("GET %s: 状态码 %d, want %d", path, rec.Code, want)
+			}
+		}
+	}
+}
+
+func TestAbuseProtection(t *testing.T) {
+	saved := appConfig.Limits
+	defer func() { appConfig.Limits = saved }()
+	appConfig.Limits.IPRate = 0.001
+	appConfig.Limits.IPBurst = 2
+	appConfig.Limits.MaxBodyBytes = 256
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/parse", handleParse)
+	server, err := newHTTPServer(":0", mux)
+	if err != nil {
+		t.Fatalf("创建服务器失败: %v", err)
+	}
+	if server.ReadHeaderTimeout == 0 || server.WriteTimeout == 0 || server.IdleTimeout == 0 {
+		t.Errorf("应设置超时: %+v", server)
+	}
+
+	post := func(body io.Reader, contentLength int64) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/parse", body)
+		req.ContentLength = contentLength
+		rec := httptest.NewRecorder()
+		server.Handler.ServeHTTP(rec, req)
+		return rec
+	}
+	errorOf := func(rec *httptest.ResponseRecorder) string {
+		var resp struct {
+			Success bool   `json:"success"`
+			Error   string `json:"error"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.Success {
+			t.Fatalf("错误响应格式不一致: %s", rec.Body.String())
+		}
+		return resp.Error
+	}
+
+	// 未声明长度的超大请求体由 MaxBytesReader 拦截；声明长度超限直接拒绝
+	large := `{"qrCode": "` + strings.Repeat("0", 1024) + `"}`
+	if rec := post(strings.NewReader(large), -1); rec.Code != http.StatusRequestEntityTooLarge || errorOf(rec) == "" {
+		t.Errorf("超大请求体应返回 413, got %d", rec.Code)
+	}
+
+	if rec := post(strings.NewReader(large), int64(len(large))); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("声明长度超限应返回 413, got %d", rec.Code)
+	}
+
+	// 第 3 个请求超出 IP 突发上限
+	rec := post(strings.NewReader(`{"qrCode": "000201"}`), -1)
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" || errorOf(rec) == "" {
+		t.Errorf("超出限流应返回 429, got %d", rec.Code)
+	}
+}
+
+// crc16Hex 计算 EMVCo CRC-16/CCITT-FALSE
+func crc16Hex(s string) string {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%04X", crc)
+}
+
+func TestGracefulShutdown(t *testing.T) {
+	s := newAPIServer("127.0.0.1:0")
+	started := make(chan struct{})
+	s.mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("done"))
+	})
+
+	// 启动前未就绪
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("启动前 /readyz 应返回 503, got %d", rec.Code)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("监听失败: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	served := make(chan error, 1)
+	go func() { served <- s.Serve(ctx, ln) }()
+
+	base := "http://" + ln.Addr().String()
+	for _, path := range []string{"/livez", "/readyz"} {
+		var resp *http.Response
+		for i := 0; i < 50; i++ {
+			if resp, err = http.Get(base + path); err == nil && resp.StatusCode == http.StatusOK {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if err != nil || resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s 应返回 200: %v", path, err)
+		}
+		resp.Body.Close()
+	}
+
+	// 处理中的请求在关闭时完成
+	slow := make(chan string, 1)
+	go func() {
+		resp, err := http.Get(base + "/slow")
+		if err != nil {
+			slow <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		slow <- string(body)
+	}()
+	<-started
+	cancel()
+
+	if got := <-slow; got != "done" {
+		t.Errorf("处理中的请求应完成, got %q", got)
+	}
+	if err := <-served; err != nil {
+		t.Errorf("优雅退出不应返回错误: %v", err)
+	}
+	if s.ready.Load() {
+		t.Error("关闭后应为未就绪")
+	}
+	if _, err := http.Get(base + "/livez"); err == nil {
+		t.Error("关闭后不应再接收连接")
+	}
+}
+
+func TestServerURL(t *testing.T) {
+	tests := []struct {
+		addr string
+		tls  bool
+		want string
+	}{
+		{":9000", false, "http://localhost:9000"},
+		{"0.0.0.0:8443", true, "https://localhost:8443"},
+		{"127.0.0.1:8080", false, "http://127.0.0.1:8080"},
+		{"[::]:9000", false, "http://localhost:9000"},
+	}
+	for _, tt := range tests {
+		if got := serverURL(tt.addr, tt.tls); got != tt.want {
+			t.Errorf("serverURL(%q, %v) = %q, want %q", tt.addr, tt.tls, got, tt.want)
+		}
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	// HTTP 请求按路由模式统计
+	s := newAPIServer(":0")
+	for _, path := range []string{"/api/orders/A-1", "/api/orders/A-2", "/livez"} {
+		s.mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type 错误: %s", ct)
+	}
+	for _, want := range []string{
+		`gcash_deeplink_http_requests_total{endpoint="/api/orders/",method="GET",code="404"} 2`,
+		`gcash_deeplink_http_request_duration_seconds_count{endpoint="/livez"}`,
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("缺少指标 %s", want)
+		}
+	}
+	if strings.Contains(rec.Body.String(), "A-1") {
+		t.Error("路径参数不应作为标签")
+	}
+}
+
+func TestRequestLogging(t *testing.T) {
+	var buf bytes.Buffer
+	saved := appLogger
+	defer func() { appLogger = saved }()
+	appLogger = slog.New(slog.NewJSONHandler(&buf, nil))
+
+	qrCode := "00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275"
+	server, err := newHTTPServer(":0", newAPIServer(":0").mux)
+	if err != nil {
+		t.Fatal(err)
+	}
+	post := func(requestID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/parse", strings.NewReader(`{"qrCode": "`+qrCode+`"}`))
+		if requestID != "" {
+			req.Header.Set(logging.HeaderRequestID, requestID)
+		}
+		rec := httptest.NewRecorder()
+		server.Handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// 沿用客户端请求 ID
+	if got := post("req-42").Header().Get(logging.HeaderRequestID); got != "req-42" {
+		t.Errorf("应回写请求 ID, got %q", got)
+	}
+	var parseLog, accessLog map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("日志应为 JSON: %s", line)
+		}
+		switch entry["msg"] {
+		case "parse":
+			parseLog = entry
+		case "http request":
+			accessLog = entry
+		}
+	}
+	if parseLog == nil || parseLog["request_id"] != "req-42" || parseLog["outcome"] != "strict" || parseLog["duration_ms"] == nil {
+		t.Errorf("解析日志错误: %v", parseLog)
+	}
+	if accessLog == nil || accessLog["request_id"] != "req-42" || accessLog["status"] != float64(200) {
+		t.Errorf("访问日志错误: %v", accessLog)
+	}
+	if strings.Contains(buf.String(), "MRCHNT-4H3TZ") || parseLog["qr"] != models.HashValue(qrCode)[:12] {
+		t.Errorf("QR 数据应脱敏: %v", parseLog["qr"])
+	}
+
+	// 未传入或非法的请求 ID 重新生成
+	for _, id := range []string{"", "bad id\n", strings.Repeat("a", 200)} {
+		if got := post(id).Header().Get(logging.HeaderRequestID); got == id || len(got) != 32 {
+			t.Errorf("请求 ID %q 应重新生成, got %q", id, got)
+		}
+	}
+}
+
+func TestAuditFailureStatus(t *testing.T) {
+	// 审计写入失败时不返回链接，HTTP 返回 500
+	g := generator.NewDeepLinkGenerator()
+	g.SetAuditSink(models.AuditSinkFunc(func(models.AuditRecord) error { return errors.New("disk full") }))
+	_, err := g.GenerateWithValidation("00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275", &models.DeepLinkOptions{OrderID: "A-4"})
+	if err == nil {
+		t.Fatal("审计写入失败时生成应失败")
+	}
+	if status := generateStatus(err); status != http.StatusInternalServerError {
+		t.Errorf("审计写入失败应返回 500, got %d", status)
+	}
+}
+
+func TestAPIVersioning(t *testing.T) {
+	const qrCode = "00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275"
+	s := newAPIServer("127.0.0.1:0")
+	call := func(method, path, body string) (*httptest.ResponseRecorder, map[string]json.RawMessage) {
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		var resp map[string]json.RawMessage
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s %s 响应不是 JSON: %s", method, path, rec.Body.String())
+		}
+		return rec, resp
+	}
+	v1Error := func(resp map[string]json.RawMessage) models.APIError {
+		var e models.APIError
+		if err := json.Unmarshal(resp["error"], &e); err != nil || e.Code == "" || e.Message == "" {
+			t.Fatalf("/v1 错误响应应为 {error: {code, message}}: %s", resp["error"])
+		}
+		return e
+	}
+
+	// 成功：/v1 为类型化响应，/api 保持 success 字段
+	rec, resp := call(http.MethodPost, "/v1/parse", `{"qrCode": "`+qrCode+`"}`)
+	if rec.Code != http.StatusOK || resp["data"] == nil {
+		t.Fatalf("/v1/parse 失败: %d %s", rec.Code, rec.Body.String())
+	}
+	if _, ok := resp["success"]; ok {
+		t.Errorf("/v1 响应不应包含 success: %s", rec.Body.String())
+	}
+	if _, resp = call(http.MethodPost, "/api/parse", `{"qrCode": "`+qrCode+`"}`); string(resp["success"]) != "true" || resp["data"] == nil {
+		t.Errorf("/api/parse 响应格式变化: %v", resp)
+	}
+	if _, resp = call(http.MethodGet, "/v1/banks", ""); resp["banks"] == nil {
+		t.Errorf("/v1/banks 缺少 banks: %v", resp)
+	}
+
+	// 错误：/v1 统一为错误信封，/api 保持 {success: false, error: "..."}
+	tests := []struct {
+		method, path, body string
+		status             int
+		code               string
+	}{
+		{http.MethodPost, "/v1/parse", `{bad`, http.StatusBadRequest, models.CodeInvalidJSON},
+		{http.MethodGet, "/v1/parse", "", http.StatusMethodNotAllowed, models.CodeMethodNotAllowed},
+		{http.MethodPost, "/v1/parse", `{"qrCode": "nonsense"}`, http.StatusBadRequest, models.CodeQRMalformed},
+		{http.MethodPost, "/v1/generate", `{}`, http.StatusBadRequest, models.CodeQREmpty},
+		{http.MethodGet, "/v1/banks?bic=NOPE", "", http.StatusNotFound, models.CodeUnknownBank},
+		{http.MethodGet, "/v1/orders/ORDER-NONE", "", http.StatusNotFound, models.CodeOrderNotFound},
+	}
+	for _, tt := range tests {
+		rec, resp := call(tt.method, tt.path, tt.body)
+		if rec.Code != tt.status {
+			t.Errorf("%s %s: 状态码 %d, want %d", tt.method, tt.path, rec.Code, tt.status)
+		}
+		if e := v1Error(resp); e.Code != tt.code {
+			t.Errorf("%s %s: code %q, want %q", tt.method, tt.path, e.Code, tt.code)
+		}
+	}
+	rec, resp = call(http.MethodPost, "/api/parse", `{bad`)
+	var legacy, legacyCode string
+	if rec.Code != http.StatusBadRequest || string(resp["success"]) != "false" || json.Unmarshal(resp["error"], &legacy) != nil ||
+		json.Unmarshal(resp["code"], &legacyCode) != nil || legacyCode != models.CodeInvalidJSON {
+		t.Errorf("/api 错误响应格式变化: %s", rec.Body.String())
+	}
+
+	// 认证与限流错误同样使用错误信封
+	keys, err := auth.LoadKeyStore(filepath.Join(t.TempDir(), "keys.json"))
+	if err != nil {
+		t.Fatalf("加载密钥文件失败: %v", err)
+	}
+	rec = httptest.NewRecorder()
+	auth.Require(keys, auth.ScopeParse, http.HandlerFunc(handleParse)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/parse", nil))
+	if e := v1Error(map[string]json.RawMessage{"error": errorField(t, rec)}); rec.Code != http.StatusUnauthorized || e.Code != models.CodeUnauthorized {
+		t.Errorf("401 错误: %d %+v", rec.Code, e)
+	}
+	limited := middleware.RateLimit(middleware.NewLimiter(0.001, 1), middleware.NewLimiter(0, 0), 0, nil, http.HandlerFunc(handleBanks))
+	for i := 0; i < 2; i++ {
+		rec = httptest.NewRecorder()
+		limited.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/banks", nil))
+	}
+	if e := v1Error(map[string]json.RawMessage{"error": errorField(t, rec)}); rec.Code != http.StatusTooManyRequests || e.Code != models.CodeRateLimited {
+		t.Errorf("429 错误: %d %+v", rec.Code, e)
+	}
+
+	// OpenAPI 文档：包含全部 /v1 接口，所有 $ref 均可解析
+	rec = httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/openapi.json", nil))
+	var doc struct {
+		OpenAPI    string                                       `json:"openapi"`
+		Paths      map[string]map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil || !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Fatalf("OpenAPI 文档无效: %v", err)
+	}
+	for _, op := range []struct{ path, method string }{
+		{"/v1/parse", "post"}, {"/v1/validate", "post"}, {"/v1/generate", "post"},
+		{"/v1/generate/strategies", "get"}, {"/v1/generate/strategies", "post"},
+		{"/v1/profiles", "get"}, {"/v1/banks", "get"}, {"/v1/orders/{orderId}", "get"},
+		{"/v1/webhooks/dead-letters", "get"}, {"/v1/webhooks/dead-letters/{id}/replay", "post"},
+	} {
+		if doc.Paths[op.path][op.method] == nil {
+			t.Errorf("OpenAPI 缺少 %s %s", op.method, op.path)
+		}
+	}
+	for _, name := range []string{"ParseRequest", "GenerateRequest", "DeepLinkResult", "ErrorResponse", "EMVCoData"} {
+		if doc.Components.Schemas[name] == nil {
+			t.Errorf("OpenAPI 缺少 schema %s", name)
+		}
+	}
+	for _, ref := range regexpRefs.FindAllStringSubmatch(rec.Body.String(), -1) {
+		if doc.Components.Schemas[ref[1]] == nil {
+			t.Errorf("无法解析的 $ref: %s", ref[0])
+		}
+	}
+}
+
+var regexpRefs = regexp.MustCompile(`"#/components/schemas/([^"]+)"`)
+
+// errorField 响应中的 error 字段
+func errorField(t *testing.T, rec *httptest.ResponseRecorder) json.RawMessage {
+	t.Helper()
+	var resp map[string]json.RawMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("响应不是 JSON: %s", rec.Body.String())
+	}
+	return resp["error"]
+}
+
+func TestErrorLocalization(t *testing.T) {
+	s := newAPIServer("127.0.0.1:0")
+	call := func(path, body, lang string) (*httptest.ResponseRecorder, string) {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		if lang != "" {
+			req.Header.Set("Accept-Language", lang)
+		}
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, req)
+		var resp struct {
+			Error json.RawMessage `json:"error"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		return rec, string(resp.Error)
+	}
+
+	tests := []struct {
+		path, body, lang, want string
+	}{
+		{"/v1/parse", `{"qrCode": "nonsense"}`, "", "TLV 解析失败: 位置 2 长度无效"},
+		{"/v1/parse", `{"qrCode": "nonsense"}`, "en", "Malformed QR code: invalid length at position 2"},
+		{"/v1/parse", `{"qrCode": "nonsense"}`, "fil-PH", "Sira ang QR code: hindi wastong haba sa posisyon 2"},
+		{"/v1/generate", `{"qrCode": "nonsense"}`, "en-US", "Malformed QR code: invalid length at position 2"},
+		{"/v1/generate", `{"qrCode": "00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275", "redirectUrl": "ftp://x"}`, "en", "Invalid callback URL"},
+		{"/v1/parse", `{bad`, "tl", "Hindi wastong JSON ang laman ng kahilingan"},
+		{"/api/parse", `{"qrCode": ""}`, "en", "QR code data is empty"},
+	}
+	for _, tt := range tests {
+		rec, body := call(tt.path, tt.body, tt.lang)
+		if !strings.Contains(body, tt.want) {
+			t.Errorf("%s [%s]: %s, want %q", tt.path, tt.lang, body, tt.want)
+		}
+		if lang := rec.Header().Get("Content-Language"); lang != string(i18n.Negotiate(tt.lang)) {
+			t.Errorf("%s [%s]: Content-Language %q", tt.path, tt.lang, lang)
+		}
+	}
+
+	// 验证结果按错误码翻译
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/validate", strings.NewReader(`{"qrCode": ""}`))
+	req.Header.Set("Accept-Language", "en")
+	s.mux.ServeHTTP(rec, req)
+	var validation models.ValidationResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &validation); err != nil || len(validation.Errors) != 1 || validation.Errors[0] != "QR code data is empty" {
+		t.Errorf("验证结果未翻译: %s", rec.Body.String())
+	}
+}
+
+func TestGenerateRequestOptions(t *testing.T) {
+	const qrCode = "00020101021228600011ph.ppmi.p2m0111DCPHPHM1XXX031920828990834787223040503011520448165303608540410005802PH5909BuzhuYazi6011Baguio city62380011ph.ppmi.p2m051921653329512971919506304EC47"
+	s := newAPIServer("127.0.0.1:0")
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/generate", strings.NewReader(body)))
+		return rec
+	}
+
+	// 全部 DeepLinkOptions 字段均可通过 HTTP 指定
+	rec := post(`{"qrCode": "` + qrCode + `", "clientId": "CLIENT-1", "shopId": "SHOP-1", "bizNo": "BIZ-1",
+		"orderAmount": "1000", "qrFormat": "legacy", "profile": "` + appConfig.DefaultProfile + `"}`)
+	var result models.GenerateResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("生成失败: %d %s", rec.Code, rec.Body.String())
+	}
+	resolved := result.Resolved
+	if resolved.ClientID != "CLIENT-1" || resolved.ShopID != "SHOP-1" || resolved.BizNo != "BIZ-1" ||
+		resolved.OrderAmount != "1000.00" || resolved.QRFormat != models.QRFormatLegacy {
+		t.Errorf("选项未生效: %+v", resolved.DeepLinkOptions)
+	}
+	if resolved.QRCode != qrCode {
+		t.Errorf("qrCode 不应覆盖链接中的 QR 数据: %q", resolved.QRCode)
+	}
+	if !containsParam(result.DeepLink, "clientId", "CLIENT-1") {
+		t.Errorf("clientId 未写入链接: %s", result.DeepLink)
+	}
+
+	rec = post(`{"qrCode": "` + qrCode + `", "newQRFormat": true}`)
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil || result.QRFormat != models.QRFormatNew {
+		t.Errorf("newQRFormat 未生效: %s", rec.Body.String())
+	}
+
+	// 未知字段与类型错误返回明确的错误
+	tests := []struct {
+		body, code, message string
+	}{
+		{`{"qrCode": "x", "callbackUrl": "https://a.example"}`, models.CodeUnknownField, "未知字段: callbackUrl"},
+		{`{"qrCode": "x", "newQRFormat": "yes"}`, models.CodeInvalidField, "字段 newQRFormat 类型错误: 应为 bool"},
+		{`{"qrCode": "x", "strategies": []}`, models.CodeUnknownField, "未知字段: strategies"},
+	}
+	for _, tt := range tests {
+		rec := post(tt.body)
+		var resp models.ErrorResponse
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		if rec.Code != http.StatusBadRequest || resp.Error.Code != tt.code || resp.Error.Message != tt.message {
+			t.Errorf("%s: %d %+v", tt.body, rec.Code, resp.Error)
+		}
+	}
+
+	// OpenAPI 文档包含全部选项，仅 qrCode 必填
+	doc := openAPIDocument(apiRoutes())
+	schema := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})["GenerateRequest"].(map[string]interface{})
+	properties := schema["properties"].(map[string]interface{})
+	for _, name := range []string{"qrCode", "clientId", "shopId", "bizNo", "orderAmount", "qrFormat", "newQRFormat", "profile"} {
+		if properties[name] == nil {
+			t.Errorf("GenerateRequest schema 缺少 %s", name)
+		}
+	}
+	if required := schema["required"].([]string); len(required) != 1 || required[0] != "qrCode" {
+		t.Errorf("required 错误: %v", required)
+	}
+}
+
+func TestQRCodeEncoding(t *testing.T) {
+	// 商户名称含 '+' 与 '%'：URL 解码会把它们改写为空格或导致解码失败
+	payload := "00020101021228600011ph.ppmi.p2m0111DCPHPHM1XXX031920828990834787223040503011520448165303608540410005802PH5909Buzhu+50%6011Baguio city62380011ph.ppmi.p2m051921653329512971919506304"
+	qrCode := payload + crc16Hex(payload)
+	s := newAPIServer("127.0.0.1:0")
+	post := func(path string, body interface{}) *httptest.ResponseRecorder {
+		b, _ := json.Marshal(body)
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, bytes.NewReader(b)))
+		return rec
+	}
+
+	tests := []struct {
+		name     string
+		qrCode   string
+		encoding models.QREncoding
+	}{
+		{"默认 raw", qrCode, ""},
+		{"raw", qrCode, models.EncodingRaw},
+		{"url", url.QueryEscape(qrCode), models.EncodingURL},
+		{"base64", base64.StdEncoding.EncodeToString([]byte(qrCode)), models.EncodingBase64},
+		{"base64url 无填充", base64.RawURLEncoding.EncodeToString([]byte(qrCode)), models.EncodingBase64},
+	}
+	for _, tt := range tests {
+		rec := post("/v1/parse", models.ParseRequest{QRCode: tt.qrCode, Encoding: tt.encoding})
+		var resp models.ParseResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+			t.Fatalf("%s: %d %s", tt.name, rec.Code, rec.Body.String())
+		}
+		if resp.Data.MerchantName != "Buzhu+50%" {
+			t.Errorf("%s: 商户名称 = %q", tt.name, resp.Data.MerchantName)
+		}
+		in := resp.Input
+		if in == nil || in.QRCode != qrCode || in.Length != len(qrCode) ||
+			in.Base64 != base64.StdEncoding.EncodeToString([]byte(qrCode)) {
+			t.Errorf("%s: input = %+v", tt.name, in)
+		}
+	}
+
+	// generate、strategies、validate 同样按 encoding 解码并回显实际解析的数据
+	encoded := base64.StdEncoding.EncodeToString([]byte(qrCode))
+	var result models.GenerateResponse
+	rec := post("/v1/generate", models.GenerateRequest{QRCode: encoded, Encoding: models.EncodingBase64})
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil || !result.Success || result.Input.QRCode != qrCode {
+		t.Errorf("generate: %d %s", rec.Code, rec.Body.String())
+	}
+	var strategies models.GenerateStrategiesResponse
+	rec = post("/v1/generate/strategies", models.GenerateStrategiesRequest{GenerateRequest: models.GenerateRequest{QRCode: encoded, Encoding: models.EncodingBase64}})
+	if err := json.Unmarshal(rec.Body.Bytes(), &strategies); err != nil || strategies.Input.QRCode != qrCode {
+		t.Errorf("strategies: %d %s", rec.Code, rec.Body.String())
+	}
+	var validation models.ValidationResult
+	rec = post("/v1/validate", models.ValidateRequest{QRCode: encoded, Encoding: models.EncodingBase64})
+	if err := json.Unmarshal(rec.Body.Bytes(), &validation); err != nil || !validation.Valid || validation.Input.QRCode != qrCode {
+		t.Errorf("validate: %d %s", rec.Code, rec.Body.String())
+	}
+
+	// 未知编码与无法解码的数据返回 400
+	errorTests := []struct {
+		qrCode   string
+		encoding models.QREncoding
+		code     string
+	}{
+		{qrCode, "hex", models.CodeUnknownEncoding},
+		{"not base64!", models.EncodingBase64, models.CodeQRDecode},
+		{"100%", models.EncodingURL, models.CodeQRDecode},
+	}
+	for _, tt := range errorTests {
+		rec := post("/v1/parse", models.ParseRequest{QRCode: tt.qrCode, Encoding: tt.encoding})
+		var resp models.ErrorResponse
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		if rec.Code != http.StatusBadRequest || resp.Error.Code != tt.code {
+			t.Errorf("%s/%s: %d %+v", tt.encoding, tt.qrCode, rec.Code, resp.Error)
+		}
+	}
+}
+
+func TestGRPCService(t *testing.T) {
+	const qrCode = "00020101021228600011ph.ppmi.p2m0111DCPHPHM1XXX031920828990834787223040503011520448165303608540410005802PH5909BuzhuYazi6011Baguio city62380011ph.ppmi.p2m051921653329512971919506304EC47"
+
+	ctx := context.Background()
+	conn := serveGRPC(t, newGRPCServer("bufnet"))
+	client := deeplinkv1.NewDeepLinkServiceClient(conn)
+
+	// 健康检查与反射
+	hc, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: deeplinkv1.DeepLinkService_ServiceDesc.ServiceName})
+	if err != nil || hc.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("健康检查: %v %v", hc, err)
+	}
+	refl, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatalf("反射失败: %v", err)
+	}
+	refl.Send(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}})
+	listed, err := refl.Recv()
+	if err != nil {
+		t.Fatalf("反射失败: %v", err)
+	}
+	var services []string
+	for _, svc := range listed.GetListServicesResponse().GetService() {
+		services = append(services, svc.Name)
+	}
+	if !strings.Contains(strings.Join(services, ","), "gcashdeeplink.v1.DeepLinkService") {
+		t.Errorf("反射未列出 DeepLinkService: %v", services)
+	}
+	refl.CloseSend()
+
+	// Parse / Validate
+	parsed, err := client.Parse(ctx, &deeplinkv1.ParseRequest{QrCode: qrCode})
+	if err != nil || parsed.Data.MerchantName != "BuzhuYazi" || parsed.Data.Amount != "1000" {
+		t.Fatalf("Parse: %v %v", parsed, err)
+	}
+	validation, err := client.Validate(ctx, &deeplinkv1.ValidateRequest{QrCode: qrCode})
+	if err != nil || !validation.Valid {
+		t.Errorf("Validate: %v %v", validation, err)
+	}
+
+	// encoding 与 HTTP 接口相同，按声明的编码解码后再解析
+	encoded := base64.StdEncoding.EncodeToString([]byte(qrCode))
+	if parsed, err := client.Parse(ctx, &deeplinkv1.ParseRequest{QrCode: encoded, Encoding: "base64"}); err != nil || parsed.Data.RawData != qrCode {
+		t.Errorf("Parse base64: %v %v", parsed, err)
+	}
+	if validation, err := client.Validate(ctx, &deeplinkv1.ValidateRequest{QrCode: encoded, Encoding: "base64"}); err != nil || !validation.Valid {
+		t.Errorf("Validate base64: %v %v", validation, err)
+	}
+	if generated, err := client.Generate(ctx, &deeplinkv1.GenerateRequest{QrCode: encoded, Encoding: "base64"}); err != nil || generated.ParsedData.RawData != qrCode {
+		t.Errorf("Generate base64: %v %v", generated, err)
+	}
+	if _, err := client.Parse(ctx, &deeplinkv1.ParseRequest{QrCode: qrCode, Encoding: "hex"}); status.Code(err) != codes.InvalidArgument ||
+		status.Convert(err).Details()[0].(*errdetails.ErrorInfo).Reason != models.CodeUnknownEncoding {
+		t.Errorf("未知的 encoding 应返回 InvalidArgument: %v", err)
+	}
+
+	// Generate 与 HTTP 使用同一个生成器
+	generated, err := client.Generate(ctx, &deeplinkv1.GenerateRequest{QrCode: qrCode, Options: &deeplinkv1.DeepLinkOptions{ClientId: "CLIENT-1", QrFormat: "legacy"}})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	expected, _ := newGenerator(ctx).GenerateWithValidation(qrCode, &models.DeepLinkOptions{ClientID: "CLIENT-1", QRFormat: models.QRFormatLegacy})
+	if generated.DeepLink != expected.DeepLink || generated.Resolved.Options.ClientId != "CLIENT-1" || generated.QrFormat != "legacy" {
+		t.Errorf("Generate 结果与 HTTP 不一致: %s", generated.DeepLink)
+	}
+
+	// 错误：状态码、ErrorInfo 中的错误码与按 accept-language 翻译的消息
+	enCtx := metadata.AppendToOutgoingContext(ctx, "accept-language", "en")
+	_, err = client.Parse(enCtx, &deeplinkv1.ParseRequest{})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument || st.Message() != "QR code data is empty" {
+		t.Errorf("空 QR Code: %v", err)
+	}
+	if details := st.Details(); len(details) != 1 || details[0].(*errdetails.ErrorInfo).Reason != models.CodeQREmpty {
+		t.Errorf("ErrorInfo: %v", details)
+	}
+
+	// GenerateBatch：逐个返回，单个失败不中断流
+	batch, err := client.GenerateBatch(ctx)
+	if err != nil {
+		t.Fatalf("GenerateBatch: %v", err)
+	}
+	requests := []*deeplinkv1.GenerateRequest{
+		{QrCode: qrCode},
+		{QrCode: qrCode, Options: &deeplinkv1.DeepLinkOptions{OrderAmount: "abc"}},
+		{QrCode: qrCode, Options: &deeplinkv1.DeepLinkOptions{PaymentType: "001"}},
+	}
+	for _, req := range requests {
+		if err := batch.Send(req); err != nil {
+			t.Fatalf("发送失败: %v", err)
+		}
+	}
+	batch.CloseSend()
+	var results []*deeplinkv1.GenerateBatchResponse
+	for {
+		resp, err := batch.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("接收失败: %v", err)
+		}
+		results = append(results, resp)
+	}
+	if len(results) != 3 || results[0].GetResult() == nil || results[2].GetResult() == nil ||
+		results[1].GetError().GetCode() != models.CodeAmountFormat || results[1].Index != 1 {
+		t.Errorf("GenerateBatch 结果: %v", results)
+	}
+
+	// DecodeDeepLink 按参数布局还原字段
+	decoded, err := client.DecodeDeepLink(ctx, &deeplinkv1.DecodeDeepLinkRequest{DeepLink: generated.DeepLink})
+	if err != nil {
+		t.Fatalf("DecodeDeepLink: %v", err)
+	}
+	if decoded.Profile != generated.Resolved.Options.Profile || decoded.Fields["clientId"] != "CLIENT-1" ||
+		decoded.Fields["shopId"] != generated.Resolved.Options.ShopId || decoded.ParsedData.GetRawData() != qrCode || decoded.Error != nil {
+		t.Errorf("DecodeDeepLink: %v", decoded)
+	}
+	if _, err := client.DecodeDeepLink(ctx, &deeplinkv1.DecodeDeepLinkRequest{DeepLink: "%zz"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("无效链接应返回 InvalidArgument: %v", err)
+	}
+
+	// 启用认证后按方法校验 scope
+	keys, err := auth.LoadKeyStore(filepath.Join(t.TempDir(), "keys.json"))
+	if err != nil {
+		t.Fatalf("加载密钥文件失败: %v", err)
+	}
+	parseOnly, _, _ := keys.Create("parser", []auth.Scope{auth.ScopeParse}, nil)
+	apiKeys = keys
+	defer func() { apiKeys = nil }()
+
+	authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+parseOnly)
+	if _, err := client.Parse(ctx, &deeplinkv1.ParseRequest{QrCode: qrCode}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("缺少 Key 应返回 Unauthenticated: %v", err)
+	}
+	if _, err := client.Parse(authCtx, &deeplinkv1.ParseRequest{QrCode: qrCode}); err != nil {
+		t.Errorf("parse scope 应可调用 Parse: %v", err)
+	}
+	if _, err := client.Generate(authCtx, &deeplinkv1.GenerateRequest{QrCode: qrCode}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("parse scope 不应可调用 Generate: %v", err)
+	}
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Errorf("健康检查无需认证: %v", err)
+	}
+}
+
+func TestGRPCLimits(t *testing.T) {
+	const qrCode = "00020101021228600011ph.ppmi.p2m0111DCPHPHM1XXX031920828990834787223040503011520448165303608540410005802PH5909BuzhuYazi6011Baguio city62380011ph.ppmi.p2m051921653329512971919506304EC47"
+
+	// 与 HTTP 共用 limits：每个 IP 突发 3 次，每个批量流最多 2 条
+	saved := appConfig.Limits
+	appConfig.Limits.IPRate, appConfig.Limits.IPBurst, appConfig.Limits.MaxBatch = 0.001, 3, 2
+	s := newGRPCServer("bufnet")
+	appConfig.Limits = saved
+	conn := serveGRPC(t, s)
+	client := deeplinkv1.NewDeepLinkServiceClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "accept-language", "en")
+
+	batch, err := client.GenerateBatch(ctx)
+	if err != nil {
+		t.Fatalf("GenerateBatch: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		batch.Send(&deeplinkv1.GenerateRequest{QrCode: qrCode})
+	}
+	batch.CloseSend()
+	received := 0
+	for {
+		_, err = batch.Recv()
+		if err != nil {
+			break
+		}
+		received++
+	}
+	if st := status.Convert(err); received != 2 || st.Code() != codes.ResourceExhausted || st.Message() != "A batch may contain at most 2 requests" {
+		t.Errorf("超过批量上限应返回 RESOURCE_EXHAUSTED: %d %v", received, err)
+	}
+
+	// 批量流计 1 次，再调用 2 次后超出突发上限
+	for i := 0; i < 2; i++ {
+		if _, err := client.Parse(ctx, &deeplinkv1.ParseRequest{QrCode: qrCode}); err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+	}
+	_, err = client.Parse(ctx, &deeplinkv1.ParseRequest{QrCode: qrCode})
+	if st := status.Convert(err); st.Code() != codes.ResourceExhausted || st.Details()[0].(*errdetails.ErrorInfo).Reason != models.CodeRateLimited {
+		t.Errorf("超出限流应返回 RESOURCE_EXHAUSTED: %v", err)
+	}
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Errorf("健康检查不应限流: %v", err)
+	}
+}
+
+// serveGRPC 通过 bufconn 在进程内启动 s 并返回客户端连接，测试结束时关闭
+func serveGRPC(t *testing.T, s *grpcServer) *grpc.ClientConn {
+	t.Helper()
+	ln := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(ctx, ln) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("gRPC 服务退出错误: %v", err)
+		}
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("连接失败: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestStaticFiles(t *testing.T) {
+	// 内置页面与工作目录无关
+	wd, _ := os.Getwd()
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	get := func(h http.Handler, path string, header ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	s := newAPIServer("127.0.0.1:0")
+	rec := get(s.mux, "/")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "<html") {
+		t.Fatalf("内置首页: %d", rec.Code)
+	}
+	if rec.Header().Get("Cache-Control") != "no-cache" || rec.Header().Get("ETag") == "" {
+		t.Errorf("缓存头: %v", rec.Header())
+	}
+	if got := get(s.mux, "/", "If-None-Match", rec.Header().Get("ETag")); got.Code != http.StatusNotModified {
+		t.Errorf("If-None-Match 应返回 304, got %d", got.Code)
+	}
+
+	// SPA 回退：无扩展名的未知路径返回首页，带扩展名的返回 404，API 路径返回 JSON 404
+	if got := get(s.mux, "/history/abc"); got.Code != http.StatusOK || got.Body.String() != rec.Body.String() {
+		t.Errorf("SPA 回退: %d", got.Code)
+	}
+	if got := get(s.mux, "/missing.js"); got.Code != http.StatusNotFound {
+		t.Errorf("缺失的资源应返回 404, got %d", got.Code)
+	}
+	got := get(s.mux, "/v1/nope")
+	var resp models.ErrorResponse
+	if json.Unmarshal(got.Body.Bytes(), &resp); got.Code != http.StatusNotFound || resp.Error.Code != models.CodeNotFound {
+		t.Errorf("未知 API 路径: %d %s", got.Code, got.Body.String())
+	}
+
+	// 覆盖目录
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "index.html"), []byte("<html>custom</html>"), 0o644)
+	os.WriteFile(filepath.Join(dir, "app.js"), []byte("console.log(1)"), 0o644)
+	h := staticHandler(dir)
+	if got := get(h, "/orders"); got.Body.String() != "<html>custom</html>" {
+		t.Errorf("覆盖目录首页: %s", got.Body.String())
+	}
+	if got := get(h, "/app.js"); got.Header().Get("Cache-Control") != "public, max-age=86400" || !strings.Contains(got.Header().Get("Content-Type"), "javascript") {
+		t.Errorf("静态资源: %v", got.Header())
+	}
+	if got := get(h, "/../main.go"); got.Code != http.StatusNotFound {
+		t.Errorf("不应读取目录之外的文件: %d", got.Code)
+	}
+}
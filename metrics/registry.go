@@ -0,0 +1,260 @@
+// Package metrics 进程内指标，以 Prometheus 文本格式输出
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// 指标名前缀
+const namespace = "gcash_deeplink_"
+
+// 延迟分桶（秒）
+var (
+	// HTTPBuckets HTTP 请求
+	HTTPBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+	// PipelineBuckets 解析 / 生成（通常在微秒级）
+	PipelineBuckets = []float64{0.00005, 0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.1}
+)
+
+// Registry 指标注册表，实现 models.MetricsHook，可同时用于 parser 与 generator
+type Registry struct {
+	mu       sync.Mutex
+	families []*family
+
+	requests         *family // endpoint, method, code
+	requestDuration  *family // endpoint
+	parses           *family // outcome
+	parseDuration    *family // outcome
+	links            *family // payment_type, merchant
+	generateFailures *family
+	generateDuration *family
+	validations      *family // valid
+	validationErrors *family // code
+
+	merchants map[string]bool // 作为 merchant 标签值的已知商户
+}
+
+var _ models.MetricsHook = (*Registry)(nil)
+
+// NewRegistry 创建注册表
+func NewRegistry() *Registry {
+	r := &Registry{}
+	r.requests = r.counter("http_requests_total", "HTTP 请求数", "endpoint", "method", "code")
+	r.requestDuration = r.histogram("http_request_duration_seconds", "HTTP 请求处理耗时", HTTPBuckets, "endpoint")
+	r.parses = r.counter("parse_total", "QR Code 解析次数（strict / fallback / failed）", "outcome")
+	r.parseDuration = r.histogram("parse_duration_seconds", "QR Code 解析耗时", PipelineBuckets, "outcome")
+	r.links = r.counter("links_generated_total", "生成的 Deep Link 数", "payment_type", "merchant")
+	r.generateFailures = r.counter("generate_failures_total", "Deep Link 生成失败次数")
+	r.generateDuration = r.histogram("generate_duration_seconds", "Deep Link 生成耗时", PipelineBuckets)
+	r.validations = r.counter("validations_total", "QR Code 验证次数", "valid")
+	r.validationErrors = r.counter("validation_errors_total", "QR Code 验证错误（按错误码）", "code")
+	return r
+}
+
+// SetMerchants 设置已知商户（来自配置与 API Key），替换之前的列表
+// merchant 标签只使用已知商户，避免调用方传入任意商户 ID 导致序列无限增长
+func (r *Registry) SetMerchants(ids []string) {
+	merchants := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id != "" {
+			merchants[id] = true
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.merchants = merchants
+}
+
+// ObserveParse 实现 models.MetricsHook
+func (r *Registry) ObserveParse(event models.ParseEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.parses.with(string(event.Outcome)).count++
+	r.parseDuration.with(string(event.Outcome)).observe(event.Duration.Seconds())
+}
+
+// ObserveValidation 实现 models.MetricsHook
+func (r *Registry) ObserveValidation(result *models.ValidationResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.validations.with(strconv.FormatBool(result.Valid)).count++
+	for _, code := range result.Codes {
+		r.validationErrors.with(code).count++
+	}
+}
+
+// ObserveGenerate 实现 models.MetricsHook；商户为空时记为 "none"，不在已知列表中时记为 "other"
+func (r *Registry) ObserveGenerate(event models.GenerateEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.generateDuration.with().observe(event.Duration.Seconds())
+	resolved := event.Resolved
+	if event.Err != nil || resolved == nil {
+		r.generateFailures.with().count++
+		return
+	}
+	merchant := resolved.MerchantID
+	switch {
+	case merchant == "":
+		merchant = "none"
+	case !r.merchants[merchant]:
+		merchant = "other"
+	}
+	r.links.with(string(resolved.PaymentType), merchant).count++
+}
+
+// ObserveRequest 记录一次 HTTP 请求
+func (r *Registry) ObserveRequest(endpoint, method string, code int, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests.with(endpoint, method, strconv.Itoa(code)).count++
+	r.requestDuration.with(endpoint).observe(duration.Seconds())
+}
+
+// Instrument 记录 next 的请求数与耗时；endpoint 应为路由模式（如 /api/orders/），避免标签基数随路径增长
+func (r *Registry) Instrument(endpoint string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, req)
+		r.ObserveRequest(endpoint, req.Method, rec.status, time.Since(start))
+	})
+}
+
+// Handler 以 Prometheus 文本格式输出全部指标
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WriteTo(w)
+	})
+}
+
+// WriteTo 以 Prometheus 文本格式写出全部指标
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var b strings.Builder
+	for _, f := range r.families {
+		f.write(&b)
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func (r *Registry) counter(name, help string, labels ...string) *family {
+	return r.register(&family{name: namespace + name, help: help, kind: "counter", labels: labels})
+}
+
+func (r *Registry) histogram(name, help string, buckets []float64, labels ...string) *family {
+	return r.register(&family{name: namespace + name, help: help, kind: "histogram", labels: labels, buckets: buckets})
+}
+
+func (r *Registry) register(f *family) *family {
+	f.series = make(map[string]*series)
+	r.families = append(r.families, f)
+	return f
+}
+
+// family 同名指标（按标签值区分序列）
+type family struct {
+	name    string
+	help    string
+	kind    string // counter / histogram
+	labels  []string
+	buckets []float64
+	series  map[string]*series
+}
+
+// series 单个标签组合的值；counter 只使用 count
+type series struct {
+	values  []string
+	count   uint64
+	sum     float64
+	bounds  []float64
+	buckets []uint64 // 各分桶的累计数
+}
+
+// with 返回标签值对应的序列，不存在时创建
+func (f *family) with(values ...string) *series {
+	key := strings.Join(values, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{values: values, bounds: f.buckets, buckets: make([]uint64, len(f.buckets))}
+		f.series[key] = s
+	}
+	return s
+}
+
+func (s *series) observe(v float64) {
+	s.count++
+	s.sum += v
+	for i, le := range s.bounds {
+		if v <= le {
+			s.buckets[i]++
+		}
+	}
+}
+
+func (f *family) write(b *strings.Builder) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.kind)
+	keys := make([]string, 0, len(f.series))
+	for k := range f.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		s := f.series[k]
+		if f.kind == "counter" {
+			fmt.Fprintf(b, "%s%s %d\n", f.name, f.labelString(s.values, ""), s.count)
+			continue
+		}
+		for i, le := range f.buckets {
+			fmt.Fprintf(b, "%s_bucket%s %d\n", f.name, f.labelString(s.values, formatFloat(le)), s.buckets[i])
+		}
+		fmt.Fprintf(b, "%s_bucket%s %d\n", f.name, f.labelString(s.values, "+Inf"), s.count)
+		fmt.Fprintf(b, "%s_sum%s %s\n", f.name, f.labelString(s.values, ""), formatFloat(s.sum))
+		fmt.Fprintf(b, "%s_count%s %d\n", f.name, f.labelString(s.values, ""), s.count)
+	}
+}
+
+// labelString 格式化标签，le 非空时追加分桶上限
+func (f *family) labelString(values []string, le string) string {
+	pairs := make([]string, 0, len(values)+1)
+	for i, v := range values {
+		pairs = append(pairs, f.labels[i]+`="`+labelEscaper.Replace(v)+`"`)
+	}
+	if le != "" {
+		pairs = append(pairs, `le="`+le+`"`)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// statusRecorder 记录响应状态码
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// labelEscaper 标签值转义（反斜杠、双引号、换行）
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
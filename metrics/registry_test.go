@@ -0,0 +1,55 @@
+package metrics_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/generator"
+	"github.com/qinyuanmao/gcash-deeplink/metrics"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	"github.com/qinyuanmao/gcash-deeplink/parser"
+)
+
+func TestRegistry(t *testing.T) {
+	qrCode := "00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275"
+	badCRC := qrCode[:len(qrCode)-4] + "0000"
+
+	reg := metrics.NewRegistry()
+	p := parser.NewEMVCoParser()
+	p.SetMetrics(reg)
+	data, _ := p.Parse(qrCode)
+	p.Parse(badCRC)
+	p.Parse("")
+	p.Validate(badCRC)
+
+	// 未知商户不作为标签值，统一记为 other
+	reg.SetMerchants([]string{"M-1"})
+	g := generator.NewDeepLinkGenerator()
+	g.SetMetrics(reg)
+	g.GenerateWithValidation(qrCode, &models.DeepLinkOptions{MerchantID: "M-1", PaymentType: models.PaymentTypeDynamic})
+	g.GenerateWithValidation(qrCode, &models.DeepLinkOptions{OrderAmount: "abc"})
+	for i := 0; i < 2; i++ {
+		g.Generate(data, &models.DeepLinkOptions{MerchantID: fmt.Sprintf("RANDOM-%d", i), PaymentType: models.PaymentTypeDynamic})
+	}
+
+	var out strings.Builder
+	reg.WriteTo(&out)
+	for _, want := range []string{
+		`gcash_deeplink_parse_total{outcome="strict"} 3`, // 直接解析 1 次 + 生成时解析 2 次
+		`gcash_deeplink_parse_total{outcome="fallback"} 1`,
+		`gcash_deeplink_parse_total{outcome="failed"} 1`,
+		`gcash_deeplink_parse_duration_seconds_count{outcome="strict"} 3`,
+		`gcash_deeplink_validations_total{valid="false"} 1`,
+		`gcash_deeplink_validation_errors_total{code="format"} 1`,
+		`gcash_deeplink_links_generated_total{payment_type="010",merchant="M-1"} 1`,
+		`gcash_deeplink_links_generated_total{payment_type="010",merchant="other"} 2`,
+		`gcash_deeplink_generate_failures_total 1`,
+		`gcash_deeplink_generate_duration_seconds_bucket{le="+Inf"} 4`,
+		"# TYPE gcash_deeplink_generate_duration_seconds histogram",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("缺少指标 %s\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Package middleware HTTP 防护中间件：限流、请求体大小限制、并发上限
+// 错误响应与 API 保持一致（见 httperr）
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/qinyuanmao/gcash-deeplink/httperr"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// MaxBytes 限制请求体大小，超出时读取返回 *http.MaxBytesError（由 IsTooLarge 判断）
+// 已知 Content-Length 超限时直接返回 413
+func MaxBytes(limit int64, next http.Handler) http.Handler {
+	if limit <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > limit {
+			RequestTooLarge(w, r, limit)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, limit)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// IsTooLarge 错误是否由请求体超限引起
+func IsTooLarge(err error) bool {
+	var maxErr *http.MaxBytesError
+	return errors.As(err, &maxErr)
+}
+
+// RequestTooLarge 返回 413
+func RequestTooLarge(w http.ResponseWriter, r *http.Request, limit int64) {
+	httperr.Error(w, r, http.StatusRequestEntityTooLarge, &models.Error{
+		Code:    models.CodePayloadTooLarge,
+		Message: fmt.Sprintf("请求体超过 %d 字节上限", limit),
+		Params:  models.Params{"limit": strconv.FormatInt(limit, 10)},
+	})
+}
+
+// ConcurrencyLimit 限制同时处理的请求数，已满时立即返回 503
+func ConcurrencyLimit(max int, next http.Handler) http.Handler {
+	if max <= 0 {
+		return next
+	}
+	slots := make(chan struct{}, max)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+			next.ServeHTTP(w, r)
+		default:
+			w.Header().Set("Retry-After", "1")
+			httperr.Write(w, r, http.StatusServiceUnavailable, models.CodeUnavailable, "服务繁忙，请稍后重试")
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/middleware"
+)
+
+func TestMaxBytes(t *testing.T) {
+	var readErr error
+	h := middleware.MaxBytes(16, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = io.ReadAll(r.Body)
+	}))
+	large := strings.Repeat("0", 64)
+
+	// 声明长度超限直接拒绝
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(large))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge || readErr != nil {
+		t.Errorf("声明长度超限应返回 413, got %d", rec.Code)
+	}
+
+	// 未声明长度时读取超限返回 MaxBytesError
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(large))
+	req.ContentLength = -1
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if !middleware.IsTooLarge(readErr) {
+		t.Errorf("读取超限应返回 MaxBytesError, got %v", readErr)
+	}
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	limited := middleware.ConcurrencyLimit(1, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}))
+	go limited.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	<-started
+	busy := httptest.NewRecorder()
+	limited.ServeHTTP(busy, httptest.NewRequest(http.MethodGet, "/", nil))
+	close(release)
+	if busy.Code != http.StatusServiceUnavailable || busy.Header().Get("Retry-After") == "" {
+		t.Errorf("并发已满应返回 503, got %d", busy.Code)
+	}
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qinyuanmao/gcash-deeplink/auth"
+	"github.com/qinyuanmao/gcash-deeplink/httperr"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// bucket 令牌桶
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter 按 key 的令牌桶限流器，并发安全
+// 每秒补充 rate 个令牌，最多累积 burst 个；rate <= 0 表示不限流
+type Limiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+// idleTTL 空闲超过该时间的桶在清理时移除
+const idleTTL = 10 * time.Minute
+
+// NewLimiter 创建限流器
+func NewLimiter(rate float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow 消耗一个令牌；不足时返回 false 及建议的重试等待时间
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if l == nil || l.rate <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// sweep 定期移除空闲的桶，调用方需持有锁
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < idleTTL {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > idleTTL {
+			delete(l.buckets, key)
+		}
+	}
+	l.swept = now
+}
+
+// RateLimit 按客户端 IP 与 API Key 限流，超限返回 429
+// API Key 按请求携带的原始值计数，与认证结果无关，伪造的 Key 仍受 IP 限流约束
+// exempt 中的路径不限流（如来源 IP 集中、已验签的支付通知回调）
+func RateLimit(byIP, byKey *Limiter, proxyHops int, exempt []string, next http.Handler) http.Handler {
+	skip := make(map[string]bool, len(exempt))
+	for _, path := range exempt {
+		skip[path] = true
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if skip[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if ok, wait := byIP.Allow(ClientIP(r, proxyHops)); !ok {
+			tooManyRequests(w, r, wait)
+			return
+		}
+		if token := auth.TokenFromRequest(r); token != "" {
+			if ok, wait := byKey.Allow(token); !ok {
+				tooManyRequests(w, r, wait)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ClientIP 客户端 IP；proxyHops 为服务前可信反向代理的层数
+// 每层代理在 X-Forwarded-For 末尾追加对端地址，只有最右侧 proxyHops 个地址可信，
+// 客户端自行填写的地址位于左侧，不能用于识别客户端
+func ClientIP(r *http.Request, proxyHops int) string {
+	if proxyHops > 0 {
+		if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+			addrs := strings.Split(strings.Join(xff, ","), ",")
+			i := len(addrs) - proxyHops
+			if i < 0 {
+				i = 0
+			}
+			if addr := strings.TrimSpace(addrs[i]); addr != "" {
+				return addr
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func tooManyRequests(w http.ResponseWriter, r *http.Request, wait time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	httperr.Write(w, r, http.StatusTooManyRequests, models.CodeRateLimited, "请求过于频繁，请稍后重试")
+}
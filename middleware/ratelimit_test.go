@@ -0,0 +1,66 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/middleware"
+)
+
+func TestRateLimit(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	call := func(h http.Handler, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("X-API-Key", token)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// 第 3 个请求超出 IP 突发上限
+	limited := middleware.RateLimit(middleware.NewLimiter(0.001, 2), middleware.NewLimiter(0, 0), 0, nil, ok)
+	for i := 0; i < 2; i++ {
+		if rec := call(limited, "/v1/banks", ""); rec.Code != http.StatusOK {
+			t.Fatalf("突发上限内应放行, got %d", rec.Code)
+		}
+	}
+	if rec := call(limited, "/v1/banks", ""); rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("超出限流应返回 429, got %d", rec.Code)
+	}
+
+	// 按 API Key 限流
+	limited = middleware.RateLimit(middleware.NewLimiter(0, 0), middleware.NewLimiter(0.001, 1), 0, nil, ok)
+	call(limited, "/v1/banks", "key-1")
+	if rec := call(limited, "/v1/banks", "key-1"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("同一 Key 超限应返回 429, got %d", rec.Code)
+	}
+	if rec := call(limited, "/v1/banks", "key-2"); rec.Code != http.StatusOK {
+		t.Errorf("不同 Key 分别计数, got %d", rec.Code)
+	}
+
+	// exempt 中的路径不限流
+	limited = middleware.RateLimit(middleware.NewLimiter(0.001, 1), middleware.NewLimiter(0, 0), 0, []string{"/api/gcash/notify"}, ok)
+	for i := 0; i < 2; i++ {
+		if rec := call(limited, "/api/gcash/notify", ""); rec.Code == http.StatusTooManyRequests {
+			t.Error("exempt 路径不应限流")
+		}
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	// 客户端伪造的 X-Forwarded-For 左侧地址不作为客户端 IP
+	req := httptest.NewRequest(http.MethodGet, "/v1/banks", nil)
+	req.Header.Set("X-Forwarded-For", "1.1.1.1, 203.0.113.7")
+	if ip := middleware.ClientIP(req, 1); ip != "203.0.113.7" {
+		t.Errorf("应取代理追加的最右侧地址, got %s", ip)
+	}
+	if ip := middleware.ClientIP(req, 0); ip != "192.0.2.1" {
+		t.Errorf("未配置代理时应取对端地址, got %s", ip)
+	}
+	if ip := middleware.ClientIP(req, 5); ip != "1.1.1.1" {
+		t.Errorf("代理层数超过地址数时取最左侧地址, got %s", ip)
+	}
+}
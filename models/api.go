@@ -0,0 +1,116 @@
+package models
+
+// /v1 API 请求与响应类型
+// 成功响应为以下类型本身；错误响应统一为 ErrorResponse
+
+// ParseRequest POST /v1/parse
+type ParseRequest struct {
+	QRCode   string     `json:"qrCode"`             // EMVCo QR Code 数据
+	Encoding QREncoding `json:"encoding,omitempty"` // qrCode 的编码: raw（默认）/ url / base64
+}
+
+// ParseResponse POST /v1/parse
+type ParseResponse struct {
+	Data  *EMVCoData   `json:"data"`
+	Input *ParsedInput `json:"input"` // 实际解析的数据
+}
+
+// ValidateRequest POST /v1/validate
+type ValidateRequest struct {
+	QRCode   string     `json:"qrCode"`             // EMVCo QR Code 数据
+	Encoding QREncoding `json:"encoding,omitempty"` // qrCode 的编码: raw（默认）/ url / base64
+}
+
+// GenerateRequest POST /v1/generate
+// 除 qrCode 外均为 DeepLinkOptions 的字段（clientId、shopId、bizNo、orderAmount、qrFormat、newQRFormat 等）
+// qrCode 为待解析的 QR Code，不作为 DeepLinkOptions.QRCode 覆盖链接中的 QR 数据
+type GenerateRequest struct {
+	QRCode   string     `json:"qrCode"`
+	Encoding QREncoding `json:"encoding,omitempty"` // qrCode 的编码: raw（默认）/ url / base64
+	DeepLinkOptions
+}
+
+// Options 转换为生成选项
+func (req *GenerateRequest) Options() *DeepLinkOptions {
+	options := req.DeepLinkOptions
+	options.QRCode = ""
+	return &options
+}
+
+// GenerateResponse POST /v1/generate
+type GenerateResponse = DeepLinkResult
+
+// GenerateStrategiesRequest POST /v1/generate/strategies
+// Strategies 临时指定策略，为空时使用服务配置的策略集
+type GenerateStrategiesRequest struct {
+	GenerateRequest
+	Strategies []Strategy `json:"strategies,omitempty"`
+}
+
+// GenerateStrategiesResponse POST /v1/generate/strategies
+type GenerateStrategiesResponse struct {
+	ParsedData *EMVCoData       `json:"parsedData"`
+	Input      *ParsedInput     `json:"input"` // 实际解析的数据
+	Results    []StrategyResult `json:"results"`
+}
+
+// StrategiesResponse GET /v1/generate/strategies
+type StrategiesResponse struct {
+	Strategies []Strategy `json:"strategies"`
+}
+
+// ProfilesResponse GET /v1/profiles
+type ProfilesResponse struct {
+	DefaultProfile string             `json:"defaultProfile"`
+	Profiles       []ParameterProfile `json:"profiles"`
+}
+
+// BanksResponse GET /v1/banks
+type BanksResponse struct {
+	Banks []Participant `json:"banks"`
+}
+
+// BankResponse GET /v1/banks?bic=
+type BankResponse struct {
+	Bank Participant `json:"bank"`
+}
+
+// OrderResponse GET /v1/orders/{orderId}
+type OrderResponse struct {
+	Order *Order `json:"order"`
+}
+
+// ErrorResponse /v1 错误响应
+type ErrorResponse struct {
+	Error APIError `json:"error"`
+}
+
+// APIError 错误码与说明；客户端应按 Code 判断，Message 仅供展示
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// API 错误码；解析、生成与订单的错误码见 errors.go
+const (
+	CodeInvalidRequest    = "invalid_request"    // 请求体无效或缺少必填字段
+	CodeInvalidJSON       = "invalid_json"       // 请求体不是有效的 JSON
+	CodeUnknownField      = "unknown_field"      // 请求体包含未知字段
+	CodeInvalidField      = "invalid_field"      // 字段类型错误
+	CodeInvalidStrategies = "invalid_strategies" // 请求中的策略无效
+	CodeUnknownBank       = "unknown_bank"       // 未知的 BIC
+	CodeMethodNotAllowed  = "method_not_allowed" // 不支持的 HTTP 方法
+	CodeUnauthorized      = "unauthorized"       // 缺少或无效的 API Key
+	CodeInvalidSignature  = "invalid_signature"  // 支付通知签名无效
+	CodeForbidden         = "forbidden"          // API Key 无权访问该接口或商户
+	CodeNotFound          = "not_found"          // 资源不存在
+	CodeConflict          = "conflict"           // 与当前状态冲突（如订单已进入支付流程）
+	CodeGone              = "gone"               // 资源已失效（如订单已过期）
+	CodePayloadTooLarge   = "payload_too_large"  // 请求体超限
+	CodeRateLimited       = "rate_limited"       // 超出限流
+	CodeBatchTooLarge     = "batch_too_large"    // 批量请求超过条数上限
+	CodeUnavailable       = "unavailable"        // 服务繁忙或未配置
+	CodeParseFailed       = "parse_failed"       // QR Code 解析失败
+	CodeGenerateFailed    = "generate_failed"    // Deep Link 生成失败
+	CodeInternal          = "internal"           // 服务内部错误
+)
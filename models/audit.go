@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// AuditRecord 一次成功生成 Deep Link 的审计记录（用于争议处理）
+type AuditRecord struct {
+	Actor        string           `json:"actor,omitempty"`     // 调用方（API Key ID 等）
+	RequestID    string           `json:"requestId,omitempty"` // 请求 ID
+	MerchantID   string           `json:"merchantId,omitempty"`
+	OrderID      string           `json:"orderId,omitempty"`
+	QRHash       string           `json:"qrHash"`                 // QR Code 原始数据的 SHA-256
+	DeepLink     string           `json:"deepLink,omitempty"`     // 脱敏后不写入，见 Redacted
+	DeepLinkHash string           `json:"deepLinkHash,omitempty"` // Deep Link 的 SHA-256
+	Resolved     *ResolvedOptions `json:"resolved"`
+	GeneratedAt  time.Time        `json:"generatedAt"`
+}
+
+// Redacted 返回按脱敏规则处理后的副本，审计日志只保存该副本
+// QR Code 原始数据与 Deep Link 含收款账号，不写入，仅以 QRHash / DeepLinkHash 关联；
+// 收款账号与户名按 accountNumber 规则脱敏
+func (r AuditRecord) Redacted(p RedactionPolicy) AuditRecord {
+	if r.DeepLink != "" {
+		r.DeepLinkHash = HashValue(r.DeepLink)
+		r.DeepLink = ""
+	}
+	if r.Resolved != nil {
+		resolved := *r.Resolved
+		resolved.QRCode = ""
+		resolved.AccountNumber = p.Account(resolved.AccountNumber)
+		resolved.AccountName = p.Account(resolved.AccountName)
+		r.Resolved = &resolved
+	}
+	return r
+}
+
+// AuditSink 审计记录写入；写入失败时生成失败，不返回未留痕的链接
+type AuditSink interface {
+	Append(record AuditRecord) error
+}
+
+// AuditSinkFunc 函数适配器
+type AuditSinkFunc func(record AuditRecord) error
+
+// Append 实现 AuditSink
+func (f AuditSinkFunc) Append(record AuditRecord) error {
+	return f(record)
+}
@@ -0,0 +1,135 @@
+package models
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 参与机构类型
+const (
+	ParticipantUniversalBank  = "universal_bank"  // 全能银行
+	ParticipantCommercialBank = "commercial_bank" // 商业银行
+	ParticipantThriftBank     = "thrift_bank"     // 储蓄银行
+	ParticipantRuralBank      = "rural_bank"      // 农村银行
+	ParticipantDigitalBank    = "digital_bank"    // 数字银行
+	ParticipantGovernmentBank = "government_bank" // 国有银行
+	ParticipantEMI            = "emi"             // 电子货币发行机构 (e-wallet)
+)
+
+//go:embed data/qrph_participants.json
+var embeddedParticipants []byte
+
+// Participant QR Ph 参与机构（银行 / 电子钱包）
+type Participant struct {
+	BIC  string `json:"bic"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// BankDirectory BIC 机构目录，可在运行时合并更新，并发安全
+type BankDirectory struct {
+	mu    sync.RWMutex
+	byBIC map[string]Participant
+}
+
+var (
+	defaultDirectory     *BankDirectory
+	defaultDirectoryOnce sync.Once
+)
+
+// DefaultBankDirectory 返回内置目录（首次调用时从嵌入的 JSON 加载）
+func DefaultBankDirectory() *BankDirectory {
+	defaultDirectoryOnce.Do(func() {
+		defaultDirectory = NewBankDirectory(nil)
+		participants, err := ParseParticipants(embeddedParticipants)
+		if err != nil {
+			panic(fmt.Sprintf("内置机构目录格式错误: %v", err))
+		}
+		defaultDirectory.Merge(participants)
+	})
+	return defaultDirectory
+}
+
+// NewBankDirectory 创建目录
+func NewBankDirectory(participants []Participant) *BankDirectory {
+	d := &BankDirectory{byBIC: make(map[string]Participant)}
+	d.Merge(participants)
+	return d
+}
+
+// ParseParticipants 解析机构列表 JSON
+func ParseParticipants(raw []byte) ([]Participant, error) {
+	var participants []Participant
+	if err := json.Unmarshal(raw, &participants); err != nil {
+		return nil, err
+	}
+	for i, p := range participants {
+		if len(p.BIC) != 8 && len(p.BIC) != 11 {
+			return nil, fmt.Errorf("机构 #%d BIC 长度无效: %q", i+1, p.BIC)
+		}
+		if p.Name == "" {
+			return nil, fmt.Errorf("机构 %s 缺少 name", p.BIC)
+		}
+	}
+	return participants, nil
+}
+
+// LoadParticipantsFile 从 JSON 文件读取机构列表
+func LoadParticipantsFile(path string) ([]Participant, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取机构目录失败: %w", err)
+	}
+	return ParseParticipants(raw)
+}
+
+// Merge 合并机构列表，同 BIC 覆盖
+func (d *BankDirectory) Merge(participants []Participant) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, p := range participants {
+		p.BIC = normalizeBIC(p.BIC)
+		d.byBIC[p.BIC] = p
+	}
+}
+
+// Lookup 按 BIC 查找机构；分行代码未收录时回退到总行 (XXX)
+func (d *BankDirectory) Lookup(bic string) (Participant, bool) {
+	bic = normalizeBIC(bic)
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if p, ok := d.byBIC[bic]; ok {
+		return p, true
+	}
+	if len(bic) == 11 {
+		p, ok := d.byBIC[bic[:8]+"XXX"]
+		return p, ok
+	}
+	return Participant{}, false
+}
+
+// All 返回全部机构（按 BIC 排序）
+func (d *BankDirectory) All() []Participant {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	all := make([]Participant, 0, len(d.byBIC))
+	for _, p := range d.byBIC {
+		all = append(all, p)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].BIC < all[j].BIC })
+	return all
+}
+
+// normalizeBIC 统一为大写 11 位（BIC8 补 XXX）
+func normalizeBIC(bic string) string {
+	bic = strings.ToUpper(strings.TrimSpace(bic))
+	if len(bic) == 8 {
+		bic += "XXX"
+	}
+	return bic
+}
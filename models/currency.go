@@ -0,0 +1,111 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 跨境 / 多币种相关错误，可通过 errors.Is 判断
+var (
+	ErrUnsupportedCurrency = NewError(CodeUnsupportedCurrency, "GCash 不支持该货币结算")
+	ErrUnsupportedCountry  = NewError(CodeUnsupportedCountry, "GCash 不支持该国家/地区的 QR Code")
+	ErrUnsupportedNetwork  = NewError(CodeUnsupportedNetwork, "GCash 无法结算该支付网络的 QR Code")
+)
+
+// GCash P2M 结算币种与国家
+const (
+	CurrencyPHP = "608"
+	CountryPH   = "PH"
+)
+
+// Currency ISO 4217 货币
+type Currency struct {
+	Numeric  string `json:"numeric"`  // 数字代码 (Tag 53)
+	Alpha    string `json:"alpha"`    // 字母代码
+	Decimals int    `json:"decimals"` // 小数位数
+	Name     string `json:"name"`
+}
+
+// Country ISO 3166-1 国家/地区
+type Country struct {
+	Alpha2   string `json:"alpha2"` // 两位字母代码 (Tag 58)
+	Name     string `json:"name"`
+	Currency string `json:"currency"` // 本币数字代码
+}
+
+// currencies 常见 ISO 4217 货币（以亚太地区 QR 网络为主）
+var currencies = map[string]Currency{
+	"036": {Numeric: "036", Alpha: "AUD", Decimals: 2, Name: "Australian Dollar"},
+	"048": {Numeric: "048", Alpha: "BHD", Decimals: 3, Name: "Bahraini Dinar"},
+	"096": {Numeric: "096", Alpha: "BND", Decimals: 2, Name: "Brunei Dollar"},
+	"116": {Numeric: "116", Alpha: "KHR", Decimals: 2, Name: "Cambodian Riel"},
+	"156": {Numeric: "156", Alpha: "CNY", Decimals: 2, Name: "Yuan Renminbi"},
+	"344": {Numeric: "344", Alpha: "HKD", Decimals: 2, Name: "Hong Kong Dollar"},
+	"356": {Numeric: "356", Alpha: "INR", Decimals: 2, Name: "Indian Rupee"},
+	"360": {Numeric: "360", Alpha: "IDR", Decimals: 2, Name: "Rupiah"},
+	"392": {Numeric: "392", Alpha: "JPY", Decimals: 0, Name: "Yen"},
+	"410": {Numeric: "410", Alpha: "KRW", Decimals: 0, Name: "Won"},
+	"414": {Numeric: "414", Alpha: "KWD", Decimals: 3, Name: "Kuwaiti Dinar"},
+	"418": {Numeric: "418", Alpha: "LAK", Decimals: 2, Name: "Lao Kip"},
+	"458": {Numeric: "458", Alpha: "MYR", Decimals: 2, Name: "Malaysian Ringgit"},
+	"608": {Numeric: "608", Alpha: "PHP", Decimals: 2, Name: "Philippine Peso"},
+	"702": {Numeric: "702", Alpha: "SGD", Decimals: 2, Name: "Singapore Dollar"},
+	"704": {Numeric: "704", Alpha: "VND", Decimals: 0, Name: "Dong"},
+	"764": {Numeric: "764", Alpha: "THB", Decimals: 2, Name: "Baht"},
+	"784": {Numeric: "784", Alpha: "AED", Decimals: 2, Name: "UAE Dirham"},
+	"826": {Numeric: "826", Alpha: "GBP", Decimals: 2, Name: "Pound Sterling"},
+	"840": {Numeric: "840", Alpha: "USD", Decimals: 2, Name: "US Dollar"},
+	"901": {Numeric: "901", Alpha: "TWD", Decimals: 2, Name: "New Taiwan Dollar"},
+	"978": {Numeric: "978", Alpha: "EUR", Decimals: 2, Name: "Euro"},
+}
+
+// countries 常见 ISO 3166-1 国家/地区
+var countries = map[string]Country{
+	"AE": {Alpha2: "AE", Name: "United Arab Emirates", Currency: "784"},
+	"AU": {Alpha2: "AU", Name: "Australia", Currency: "036"},
+	"BH": {Alpha2: "BH", Name: "Bahrain", Currency: "048"},
+	"BN": {Alpha2: "BN", Name: "Brunei Darussalam", Currency: "096"},
+	"CN": {Alpha2: "CN", Name: "China", Currency: "156"},
+	"GB": {Alpha2: "GB", Name: "United Kingdom", Currency: "826"},
+	"HK": {Alpha2: "HK", Name: "Hong Kong", Currency: "344"},
+	"ID": {Alpha2: "ID", Name: "Indonesia", Currency: "360"},
+	"IN": {Alpha2: "IN", Name: "India", Currency: "356"},
+	"JP": {Alpha2: "JP", Name: "Japan", Currency: "392"},
+	"KH": {Alpha2: "KH", Name: "Cambodia", Currency: "116"},
+	"KR": {Alpha2: "KR", Name: "Korea, Republic of", Currency: "410"},
+	"KW": {Alpha2: "KW", Name: "Kuwait", Currency: "414"},
+	"LA": {Alpha2: "LA", Name: "Lao People's Democratic Republic", Currency: "418"},
+	"MY": {Alpha2: "MY", Name: "Malaysia", Currency: "458"},
+	"PH": {Alpha2: "PH", Name: "Philippines", Currency: "608"},
+	"SG": {Alpha2: "SG", Name: "Singapore", Currency: "702"},
+	"TH": {Alpha2: "TH", Name: "Thailand", Currency: "764"},
+	"TW": {Alpha2: "TW", Name: "Taiwan", Currency: "901"},
+	"US": {Alpha2: "US", Name: "United States of America", Currency: "840"},
+	"VN": {Alpha2: "VN", Name: "Viet Nam", Currency: "704"},
+}
+
+// LookupCurrency 按数字代码查找货币
+func LookupCurrency(numeric string) (Currency, bool) {
+	c, ok := currencies[numeric]
+	return c, ok
+}
+
+// LookupCountry 按两位字母代码查找国家/地区
+func LookupCountry(alpha2 string) (Country, bool) {
+	c, ok := countries[strings.ToUpper(alpha2)]
+	return c, ok
+}
+
+// String 如 "PHP (608)"
+func (c Currency) String() string {
+	return fmt.Sprintf("%s (%s)", c.Alpha, c.Numeric)
+}
+
+// CheckAmount 检查金额小数位是否符合该货币规则（如 JPY 不允许小数）
+func (c Currency) CheckAmount(s string) error {
+	_, frac, hasDot := strings.Cut(strings.TrimSpace(s), ".")
+	if hasDot && len(frac) > c.Decimals {
+		return ErrAmountFormat.Errorf(Params{"amount": s}, "%s 最多 %d 位小数 (%s)", c.Alpha, c.Decimals, s)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+func TestCurrencyDecimals(t *testing.T) {
+	jpy, ok := models.LookupCurrency("392")
+	if !ok || jpy.Decimals != 0 {
+		t.Fatalf("JPY 查找失败: %+v", jpy)
+	}
+	if err := jpy.CheckAmount("100.50"); err == nil {
+		t.Error("JPY 不应允许小数")
+	}
+	php, _ := models.LookupCurrency(models.CurrencyPHP)
+	if err := php.CheckAmount("100.50"); err != nil {
+		t.Errorf("PHP 两位小数应通过: %v", err)
+	}
+}
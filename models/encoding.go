@@ -0,0 +1,83 @@
+package models
+
+import (
+	"encoding/base64"
+	"net/url"
+)
+
+// QREncoding 请求中 qrCode 字段的编码方式
+// JSON 请求默认 raw：'+'、'%' 在商户名称与 Tag 62 子标签中均为合法字符，不能再做 URL 解码
+type QREncoding string
+
+const (
+	EncodingRaw    QREncoding = "raw"    // 原文（默认）
+	EncodingURL    QREncoding = "url"    // URL 编码（application/x-www-form-urlencoded，'+' 为空格）
+	EncodingBase64 QREncoding = "base64" // base64（标准或 URL 安全字母表，可省略填充）
+)
+
+// 编码相关错误，可通过 errors.Is 判断
+var (
+	ErrUnknownEncoding = NewError(CodeUnknownEncoding, "未知的 encoding")
+	ErrQRDecode        = NewError(CodeQRDecode, "QR Code 解码失败")
+)
+
+// 编码错误码
+const (
+	CodeUnknownEncoding = "unknown_encoding" // encoding 不是 raw / url / base64
+	CodeQRDecode        = "qr_decode_failed" // qrCode 不符合声明的 encoding
+)
+
+// base64Encodings Decode 依次尝试的 base64 字母表
+var base64Encodings = []*base64.Encoding{
+	base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding,
+}
+
+// Decode 按编码方式还原 QR Code 原文；空值视为 raw
+func (e QREncoding) Decode(s string) (string, error) {
+	switch e {
+	case "", EncodingRaw:
+		return s, nil
+	case EncodingURL:
+		decoded, err := url.QueryUnescape(s)
+		if err != nil {
+			return "", ErrQRDecode.Errorf(Params{"encoding": string(e)}, "%v", err)
+		}
+		return decoded, nil
+	case EncodingBase64:
+		var err error
+		for _, enc := range base64Encodings {
+			var b []byte
+			if b, err = enc.DecodeString(s); err == nil {
+				return string(b), nil
+			}
+		}
+		return "", ErrQRDecode.Errorf(Params{"encoding": string(e)}, "%v", err)
+	default:
+		return "", ErrUnknownEncoding.Errorf(Params{"encoding": string(e)}, "%q，应为 raw、url 或 base64", string(e))
+	}
+}
+
+// ParsedInput 实际解析的 QR Code 数据（按 encoding 解码后），用于核对传输过程中是否被改写
+type ParsedInput struct {
+	Encoding QREncoding `json:"encoding"`
+	QRCode   string     `json:"qrCode"` // 解码后的原文
+	Base64   string     `json:"base64"` // 原文字节的 base64，便于核对不可见字符
+	Length   int        `json:"length"` // 字节数
+}
+
+// DecodeInput 解码 qrCode 并返回实际解析的数据
+func DecodeInput(qrCode string, encoding QREncoding) (*ParsedInput, error) {
+	decoded, err := encoding.Decode(qrCode)
+	if err != nil {
+		return nil, err
+	}
+	if encoding == "" {
+		encoding = EncodingRaw
+	}
+	return &ParsedInput{
+		Encoding: encoding,
+		QRCode:   decoded,
+		Base64:   base64.StdEncoding.EncodeToString([]byte(decoded)),
+		Length:   len(decoded),
+	}, nil
+}
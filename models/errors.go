@@ -0,0 +1,100 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error 带稳定错误码的错误
+// Error() 返回中文说明（与原有错误文本一致）；HTTP 层按 Code 与 Params 渲染为请求的语言（见 i18n）
+// 错误码相同即视为同一错误：errors.Is(err, ErrAmountFormat) 对带详情的派生错误同样成立
+type Error struct {
+	Code    string
+	Message string // 中文说明
+	Params  Params // 其他语言消息模板的参数
+	Err     error  // 底层错误
+}
+
+// Params 消息模板参数，如 {"amount": "1,000"}
+type Params map[string]string
+
+// NewError 创建错误
+func NewError(code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Error 实现 error
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap 返回底层错误
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is 错误码相同即匹配
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Errorf 派生带详情的同码错误：中文消息为 "原消息: 详情"，format 中的 %w 作为底层错误
+func (e *Error) Errorf(params Params, format string, args ...interface{}) *Error {
+	detail := fmt.Errorf(format, args...)
+	return &Error{
+		Code:    e.Code,
+		Message: e.Message + ": " + detail.Error(),
+		Params:  params,
+		Err:     errors.Unwrap(detail),
+	}
+}
+
+// CodeOf 错误链中第一个 *Error 的错误码，没有时返回 CodeInternal
+func CodeOf(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return CodeInternal
+}
+
+// 解析与生成错误码（API 错误码见 api.go）
+const (
+	CodeQREmpty                = "qr_empty"                 // QR Code 为空
+	CodeQRMalformed            = "qr_malformed"             // TLV 结构无效
+	CodeDataRequired           = "data_required"            // 生成时未提供解析数据
+	CodeUnsupportedQRFormat    = "unsupported_qr_format"    // 未知的 QR 布局
+	CodeUnknownProfile         = "unknown_profile"          // 未知的参数布局
+	CodeP2PUnsupported         = "p2p_unsupported"          // P2P 转账 QR 暂不支持生成链接
+	CodeInvalidDeepLink        = "invalid_deeplink"         // Deep Link 不是有效的 URL
+	CodeAuditFailed            = "audit_failed"             // 审计记录写入失败
+	CodeUnsupportedNetwork     = "unsupported_network"      // 非 QR Ph 网络
+	CodeUnsupportedCurrency    = "unsupported_currency"     // 非 PHP
+	CodeUnsupportedCountry     = "unsupported_country"      // 非菲律宾
+	CodeAmountFormat           = "amount_format"            // 金额格式无效
+	CodeAmountRange            = "amount_range"             // 金额超出限制
+	CodeAmountMismatch         = "amount_mismatch"          // 与动态 QR 金额不一致
+	CodeMCCBlocked             = "mcc_blocked"              // 商户分类被禁止
+	CodeOrderIDRequired        = "order_id_required"        // 商户分类要求订单号
+	CodeInvalidURL             = "invalid_url"              // 回调 URL 无效
+	CodeURLNotAllowed          = "url_not_allowed"          // 回调 URL 主机不在允许列表
+	CodeOrderNotFound          = "order_not_found"          // 订单不存在
+	CodeOrderExists            = "order_exists"             // 订单已进入支付流程
+	CodeOrderExpired           = "order_expired"            // 订单已过期
+	CodeOrderClosed            = "order_closed"             // 订单已完成
+	CodeOrderInvalidTransition = "order_invalid_transition" // 订单状态转换无效
+	CodeNotificationStatus     = "notification_status"      // 支付通知状态未知
+)
+
+// 解析与生成错误，可通过 errors.Is 判断
+var (
+	ErrQREmpty             = NewError(CodeQREmpty, "QR Code 数据不能为空")
+	ErrQRMalformed         = NewError(CodeQRMalformed, "TLV 解析失败")
+	ErrDataRequired        = NewError(CodeDataRequired, "解析数据不能为空")
+	ErrUnsupportedQRFormat = NewError(CodeUnsupportedQRFormat, "不支持的 QR 格式")
+	ErrUnknownProfile      = NewError(CodeUnknownProfile, "未知的参数布局")
+	ErrP2PUnsupported      = NewError(CodeP2PUnsupported, "暂不支持为 P2P 转账 QR 生成 Deep Link")
+	ErrInvalidDeepLink     = NewError(CodeInvalidDeepLink, "Deep Link 无效")
+	ErrAuditFailed         = NewError(CodeAuditFailed, "审计记录写入失败")
+)
@@ -0,0 +1,22 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+func TestErrorCodes(t *testing.T) {
+	// 派生错误保留错误码，errors.Is 按错误码匹配
+	_, err := models.ParseAmount("1,000")
+	if !errors.Is(err, models.ErrAmountFormat) || models.CodeOf(err) != models.CodeAmountFormat {
+		t.Errorf("金额错误应为 %s: %v", models.CodeAmountFormat, err)
+	}
+	if errors.Is(err, models.ErrAmountRange) {
+		t.Error("不同错误码不应匹配")
+	}
+	if models.CodeOf(errors.New("plain")) != models.CodeInternal {
+		t.Error("无错误码的错误应为 internal")
+	}
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// EventType 事件类型
+type EventType string
+
+const (
+	EventLinkGenerated    EventType = "link.generated"
+	EventLinkOpened       EventType = "link.opened"
+	EventPaymentSucceeded EventType = "payment.succeeded"
+	EventPaymentFailed    EventType = "payment.failed"
+)
+
+// EventTypes 全部事件类型
+var EventTypes = []EventType{EventLinkGenerated, EventLinkOpened, EventPaymentSucceeded, EventPaymentFailed}
+
+// orderEvents 订单状态 → 事件
+var orderEvents = map[OrderStatus]EventType{
+	OrderLinkOpened: EventLinkOpened,
+	OrderPaid:       EventPaymentSucceeded,
+	OrderFailed:     EventPaymentFailed,
+}
+
+// Event 推送给下游系统的事件
+type Event struct {
+	ID         string      `json:"id"`
+	Type       EventType   `json:"type"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	MerchantID string      `json:"merchantId,omitempty"`
+	OrderID    string      `json:"orderId,omitempty"`
+	Data       interface{} `json:"data"`
+}
+
+// EventSink 事件接收方，Publish 不得阻塞调用方
+type EventSink interface {
+	Publish(Event)
+}
+
+// NewEvent 创建事件
+func NewEvent(eventType EventType, merchantID, orderID string, data interface{}) Event {
+	return Event{
+		ID:         NewEventID(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		MerchantID: merchantID,
+		OrderID:    orderID,
+		Data:       data,
+	}
+}
+
+// OrderEvent 订单状态转换对应的事件；没有对应事件的状态返回 false
+func OrderEvent(order *Order) (Event, bool) {
+	eventType, ok := orderEvents[order.Status]
+	if !ok {
+		return Event{}, false
+	}
+	return NewEvent(eventType, order.MerchantID, order.OrderID, order), true
+}
+
+// NewEventID 随机事件 ID
+func NewEventID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return "evt_" + hex.EncodeToString(b)
+}
+
+// LinkGeneratedData link.generated 事件内容
+// 推送前经 Event.Redacted 脱敏：DeepLink 含 QR 数据，只保留 DeepLinkHash
+type LinkGeneratedData struct {
+	DeepLink     string           `json:"deepLink,omitempty"`
+	DeepLinkHash string           `json:"deepLinkHash,omitempty"` // Deep Link 的 SHA-256，与审计记录一致
+	Resolved     *ResolvedOptions `json:"resolved"`
+}
+
+// Redacted 返回按脱敏规则处理后的事件副本，推送给下游前调用
+// Deep Link 含 QR Code 原始数据，不推送，仅以 SHA-256 关联；link.generated 的 QR Code 按 qrPayload 规则、
+// 收款账号与户名按 accountNumber 规则脱敏；订单事件不含 deepLink
+func (e Event) Redacted(p RedactionPolicy) Event {
+	switch data := e.Data.(type) {
+	case LinkGeneratedData:
+		if data.DeepLink != "" {
+			data.DeepLinkHash = HashValue(data.DeepLink)
+			data.DeepLink = ""
+		}
+		if data.Resolved != nil {
+			resolved := *data.Resolved
+			resolved.QRCode = p.QR(resolved.QRCode)
+			resolved.AccountNumber = p.Account(resolved.AccountNumber)
+			resolved.AccountName = p.Account(resolved.AccountName)
+			data.Resolved = &resolved
+		}
+		e.Data = data
+	case *Order:
+		order := data.Clone()
+		order.DeepLink = ""
+		e.Data = order
+	}
+	return e
+}
+
+// WebhookSubscription 事件订阅：MerchantID 为空或 "*" 表示所有商户，Events 为空表示所有事件
+type WebhookSubscription struct {
+	MerchantID string      `json:"merchantId,omitempty"`
+	URL        string      `json:"url"`
+	Secret     string      `json:"secret"`
+	Events     []EventType `json:"events,omitempty"`
+}
+
+// Matches 订阅是否接收该事件
+func (s WebhookSubscription) Matches(e Event) bool {
+	if s.MerchantID != "" && s.MerchantID != "*" && s.MerchantID != e.MerchantID {
+		return false
+	}
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, t := range s.Events {
+		if t == e.Type {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package models
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// 商户分类策略相关错误，可通过 errors.Is 判断
+var (
+	ErrMCCBlocked      = NewError(CodeMCCBlocked, "商户分类不允许生成支付链接")
+	ErrOrderIDRequired = NewError(CodeOrderIDRequired, "该商户分类必须提供订单号")
+)
+
+// MCC 分组
+const (
+	MCCGroupGambling = "gambling" // 博彩
+	MCCGroupAdult    = "adult"    // 成人服务
+)
+
+//go:embed data/iso18245_mcc.json
+var embeddedMCCs []byte
+
+// MerchantCategory ISO 18245 商户分类码
+type MerchantCategory struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Group       string `json:"group"`
+}
+
+var (
+	mccTable     map[string]MerchantCategory
+	mccTableOnce sync.Once
+)
+
+// LookupMCC 按四位代码查找商户分类
+func LookupMCC(code string) (MerchantCategory, bool) {
+	mccTableOnce.Do(func() {
+		var categories []MerchantCategory
+		if err := json.Unmarshal(embeddedMCCs, &categories); err != nil {
+			panic(fmt.Sprintf("内置 MCC 表格式错误: %v", err))
+		}
+		mccTable = make(map[string]MerchantCategory, len(categories))
+		for _, c := range categories {
+			mccTable[c.Code] = c
+		}
+	})
+	c, ok := mccTable[strings.TrimSpace(code)]
+	return c, ok
+}
+
+// MCCAction 命中规则后的处理
+type MCCAction string
+
+const (
+	MCCActionBlock          MCCAction = "block"            // 拒绝生成
+	MCCActionRequireOrderID MCCAction = "require_order_id" // 必须提供订单号
+)
+
+// MCCRule 商户分类规则，按代码（"7995" 或区间 "7800-7802"）或分组匹配
+type MCCRule struct {
+	Name    string    `json:"name"`
+	Codes   []string  `json:"codes,omitempty"`
+	Groups  []string  `json:"groups,omitempty"`
+	Action  MCCAction `json:"action"`
+	Message string    `json:"message,omitempty"` // 命中时附加到错误信息
+}
+
+// MCCPolicy 商户分类策略，规则按顺序评估
+type MCCPolicy struct {
+	Rules []MCCRule `json:"rules"`
+}
+
+// DefaultMCCPolicy 默认策略: 拒绝博彩类商户
+func DefaultMCCPolicy() MCCPolicy {
+	return MCCPolicy{
+		Rules: []MCCRule{
+			{Name: "block-gambling", Groups: []string{MCCGroupGambling}, Action: MCCActionBlock},
+		},
+	}
+}
+
+// Validate 校验规则：名称非空、动作已知、代码为四位数字或区间
+func (p MCCPolicy) Validate() error {
+	for i, r := range p.Rules {
+		if r.Name == "" {
+			return fmt.Errorf("MCC 规则 #%d 缺少 name", i+1)
+		}
+		if r.Action != MCCActionBlock && r.Action != MCCActionRequireOrderID {
+			return fmt.Errorf("MCC 规则 %s 的 action 无效: %q", r.Name, r.Action)
+		}
+		if len(r.Codes) == 0 && len(r.Groups) == 0 {
+			return fmt.Errorf("MCC 规则 %s 未指定 codes 或 groups", r.Name)
+		}
+		for _, c := range r.Codes {
+			lo, hi, isRange := strings.Cut(c, "-")
+			if !isMCC(lo) || (isRange && (!isMCC(hi) || hi < lo)) {
+				return fmt.Errorf("MCC 规则 %s 的代码无效: %q", r.Name, c)
+			}
+		}
+	}
+	return nil
+}
+
+// Check 评估商户分类；mcc 为空时不拦截
+func (p MCCPolicy) Check(mcc, orderID string) error {
+	if mcc == "" {
+		return nil
+	}
+	for _, r := range p.Rules {
+		if !r.matches(mcc) {
+			continue
+		}
+		var err *Error
+		switch r.Action {
+		case MCCActionBlock:
+			err = ErrMCCBlocked
+		case MCCActionRequireOrderID:
+			if orderID == "" {
+				err = ErrOrderIDRequired
+			}
+		}
+		if err != nil {
+			return err.Errorf(Params{"mcc": mcc}, "MCC %s (规则 %s)%s", mccLabel(mcc), r.Name, suffix(r.Message))
+		}
+	}
+	return nil
+}
+
+// matches 规则是否命中 mcc
+func (r MCCRule) matches(mcc string) bool {
+	for _, c := range r.Codes {
+		lo, hi, isRange := strings.Cut(c, "-")
+		if mcc == lo || (isRange && mcc >= lo && mcc <= hi) {
+			return true
+		}
+	}
+	if category, ok := LookupMCC(mcc); ok {
+		for _, g := range r.Groups {
+			if g == category.Group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mccLabel 如 "7995 Betting, ..."；未收录时仅代码
+func mccLabel(mcc string) string {
+	if c, ok := LookupMCC(mcc); ok {
+		return mcc + " " + c.Description
+	}
+	return mcc
+}
+
+// suffix 非空时以 "，" 连接
+func suffix(msg string) string {
+	if msg == "" {
+		return ""
+	}
+	return "，" + msg
+}
+
+// isMCC 是否为四位数字
+func isMCC(s string) bool {
+	return len(s) == 4 && isDigits(s)
+}
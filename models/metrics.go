@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// ParseOutcome 解析结果分类
+type ParseOutcome string
+
+const (
+	ParseStrict   ParseOutcome = "strict"   // mpm.Decode 严格解析成功（含 CRC 校验）
+	ParseFallback ParseOutcome = "fallback" // 严格解析失败，回退到宽松 TLV 解析
+	ParseFailed   ParseOutcome = "failed"   // 解析失败
+)
+
+// ParseEvent 一次 Parse 调用
+type ParseEvent struct {
+	Input    string     // QR Code 原始数据（敏感，输出前需脱敏）
+	Data     *EMVCoData // 解析结果，失败时为 nil
+	Outcome  ParseOutcome
+	Err      error
+	Duration time.Duration
+}
+
+// GenerateEvent 一次 Generate 调用
+type GenerateEvent struct {
+	Data     *EMVCoData       // 输入的解析数据
+	Resolved *ResolvedOptions // 实际生效的参数，失败时可能为 nil
+	DeepLink string           // 成功时的 Deep Link
+	Err      error
+	Duration time.Duration
+}
+
+// MetricsHook 解析、验证与生成的观测回调（指标、日志等）
+// 由 parser 与 generator 调用，库使用方可接入自己的监控系统；实现需并发安全
+type MetricsHook interface {
+	// ObserveParse 一次 Parse 调用的结果与耗时
+	ObserveParse(event ParseEvent)
+	// ObserveValidation 一次 Validate 调用的结果（含错误码）
+	ObserveValidation(result *ValidationResult)
+	// ObserveGenerate 一次 Generate 调用；成功时 Err 为空
+	ObserveGenerate(event GenerateEvent)
+}
+
+// MetricsHooks 依次调用多个回调，nil 元素被忽略
+type MetricsHooks []MetricsHook
+
+// ObserveParse 实现 MetricsHook
+func (hs MetricsHooks) ObserveParse(event ParseEvent) {
+	for _, h := range hs {
+		if h != nil {
+			h.ObserveParse(event)
+		}
+	}
+}
+
+// ObserveValidation 实现 MetricsHook
+func (hs MetricsHooks) ObserveValidation(result *ValidationResult) {
+	for _, h := range hs {
+		if h != nil {
+			h.ObserveValidation(result)
+		}
+	}
+}
+
+// ObserveGenerate 实现 MetricsHook
+func (hs MetricsHooks) ObserveGenerate(event GenerateEvent) {
+	for _, h := range hs {
+		if h != nil {
+			h.ObserveGenerate(event)
+		}
+	}
+}
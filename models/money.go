@@ -0,0 +1,141 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 金额相关错误，可通过 errors.Is 判断
+var (
+	ErrAmountFormat   = NewError(CodeAmountFormat, "金额格式无效")
+	ErrAmountRange    = NewError(CodeAmountRange, "金额超出允许范围")
+	ErrAmountMismatch = NewError(CodeAmountMismatch, "金额与动态 QR Code 不一致")
+)
+
+// amountDecimals PHP 金额小数位数
+const amountDecimals = 2
+
+// maxAmountDigits 整数部分最大位数（EMVCo Tag 54 最长 13 字符）
+const maxAmountDigits = 10
+
+// Amount 金额，以最小货币单位（centavo）存储，避免浮点误差
+type Amount int64
+
+// ParseAmount 精确解析十进制金额字符串
+// 接受 "100"、"100.5"、"100.50"；拒绝负数、千位分隔符、指数形式及超过 2 位小数
+func ParseAmount(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrAmountFormat.Errorf(Params{"amount": s}, "金额为空")
+	}
+	if strings.HasPrefix(s, "-") {
+		return 0, ErrAmountFormat.Errorf(Params{"amount": s}, "金额不能为负数 (%s)", s)
+	}
+	if strings.Contains(s, ",") {
+		return 0, ErrAmountFormat.Errorf(Params{"amount": s}, "不允许千位分隔符 (%s)", s)
+	}
+
+	intPart, fracPart, hasDot := strings.Cut(s, ".")
+	if intPart == "" || (hasDot && fracPart == "") {
+		return 0, ErrAmountFormat.Errorf(Params{"amount": s}, "%s", s)
+	}
+	if !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, ErrAmountFormat.Errorf(Params{"amount": s}, "%s", s)
+	}
+	if len(fracPart) > amountDecimals {
+		return 0, ErrAmountFormat.Errorf(Params{"amount": s}, "最多 %d 位小数 (%s)", amountDecimals, s)
+	}
+
+	intPart = strings.TrimLeft(intPart, "0")
+	if len(intPart) > maxAmountDigits {
+		return 0, ErrAmountFormat.Errorf(Params{"amount": s}, "金额过大 (%s)", s)
+	}
+	fracPart += strings.Repeat("0", amountDecimals-len(fracPart))
+
+	units, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return 0, ErrAmountFormat.Errorf(Params{"amount": s}, "%s", s)
+	}
+	return Amount(units), nil
+}
+
+// String 格式化为两位小数，如 "100.00"
+func (a Amount) String() string {
+	return fmt.Sprintf("%d.%02d", int64(a)/100, int64(a)%100)
+}
+
+// MarshalJSON 以字符串输出，避免客户端按浮点数处理
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(a.String())), nil
+}
+
+// UnmarshalJSON 接受字符串 "100.00" 或数字 100.00（按原文精确解析）
+func (a *Amount) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	v, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// AmountLimits 金额上下限，零值表示不限制
+type AmountLimits struct {
+	Min Amount `json:"min,omitempty"`
+	Max Amount `json:"max,omitempty"`
+}
+
+// Check 检查金额是否在范围内
+func (l AmountLimits) Check(a Amount) error {
+	if l.Min > 0 && a < l.Min {
+		return ErrAmountRange.Errorf(Params{"amount": a.String(), "min": l.Min.String()}, "%s 低于最小金额 %s", a, l.Min)
+	}
+	if l.Max > 0 && a > l.Max {
+		return ErrAmountRange.Errorf(Params{"amount": a.String(), "max": l.Max.String()}, "%s 超过最大金额 %s", a, l.Max)
+	}
+	return nil
+}
+
+// AmountPolicy 金额限制策略
+// 优先级: 商户 (MerchantID) > 支付类型 > 默认
+type AmountPolicy struct {
+	Default      AmountLimits                 `json:"default"`
+	PaymentTypes map[PaymentType]AmountLimits `json:"paymentTypes,omitempty"`
+	Merchants    map[string]AmountLimits      `json:"merchants,omitempty"`
+}
+
+// QRPhMaxAmount QR Ph (InstaPay) 单笔交易上限 ₱50,000.00
+const QRPhMaxAmount Amount = 50000_00
+
+// DefaultAmountPolicy 默认策略: ₱0.01 ~ QR Ph 单笔上限
+func DefaultAmountPolicy() AmountPolicy {
+	return AmountPolicy{
+		Default: AmountLimits{Min: 1, Max: QRPhMaxAmount},
+	}
+}
+
+// LimitsFor 返回适用于指定商户与支付类型的金额限制
+func (p AmountPolicy) LimitsFor(merchantID string, paymentType PaymentType) AmountLimits {
+	if l, ok := p.Merchants[merchantID]; ok {
+		return l
+	}
+	if l, ok := p.PaymentTypes[paymentType]; ok {
+		return l
+	}
+	return p.Default
+}
+
+// isDigits 判断字符串是否全为数字（空串视为 true）
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "100", want: "100.00"},
+		{input: "100.5", want: "100.50"},
+		{input: "0100.05", want: "100.05"},
+		{input: " 20.00 ", want: "20.00"},
+		{input: "1,000.00", wantErr: true},
+		{input: "-5", wantErr: true},
+		{input: "0.001", wantErr: true},
+		{input: "1e3", wantErr: true},
+		{input: "10.", wantErr: true},
+		{input: ".5", wantErr: true},
+		{input: "", wantErr: true},
+		{input: "99999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := models.ParseAmount(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, models.ErrAmountFormat) {
+					t.Errorf("ParseAmount(%q) 应返回 ErrAmountFormat, got %v", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAmount(%q) 失败: %v", tt.input, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("ParseAmount(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
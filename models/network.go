@@ -0,0 +1,40 @@
+package models
+
+import "strings"
+
+// PaymentNetwork 通过 EMVCo 互联的 QR 支付网络（Tag 26-51 GUID 识别）
+type PaymentNetwork struct {
+	Name         string   `json:"name"`
+	Country      string   `json:"country"`      // ISO 3166-1 alpha-2
+	Currency     string   `json:"currency"`     // ISO 4217 数字代码
+	GUIDPrefixes []string `json:"guidPrefixes"` // GUID 前缀（不区分大小写）
+}
+
+// NetworkQRPh 菲律宾 QR Ph（GCash 可结算）
+const NetworkQRPh = "QR Ph"
+
+// networks 已知的 QR 支付网络
+var networks = []PaymentNetwork{
+	{Name: NetworkQRPh, Country: "PH", Currency: "608", GUIDPrefixes: []string{"ph.ppmi.", "com.p2pqrpay"}},
+	{Name: "PromptPay", Country: "TH", Currency: "764", GUIDPrefixes: []string{"A000000677"}},
+	{Name: "DuitNow", Country: "MY", Currency: "458", GUIDPrefixes: []string{"A000000615", "my.com.paynet"}},
+	{Name: "PayNow", Country: "SG", Currency: "702", GUIDPrefixes: []string{"SG.PAYNOW"}},
+	{Name: "NETS", Country: "SG", Currency: "702", GUIDPrefixes: []string{"SG.COM.NETS"}},
+	{Name: "SGQR", Country: "SG", Currency: "702", GUIDPrefixes: []string{"SG.SGQR"}},
+	{Name: "QRIS", Country: "ID", Currency: "360", GUIDPrefixes: []string{"ID.CO.QRIS", "ID.CO."}},
+	{Name: "VietQR", Country: "VN", Currency: "704", GUIDPrefixes: []string{"A000000727"}},
+	{Name: "KHQR", Country: "KH", Currency: "116", GUIDPrefixes: []string{"kh.gov.nbc.bakong"}},
+}
+
+// LookupNetwork 按 GUID 识别支付网络
+func LookupNetwork(guid string) (PaymentNetwork, bool) {
+	lower := strings.ToLower(guid)
+	for _, n := range networks {
+		for _, prefix := range n.GUIDPrefixes {
+			if strings.HasPrefix(lower, strings.ToLower(prefix)) {
+				return n, true
+			}
+		}
+	}
+	return PaymentNetwork{}, false
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// ErrNotificationStatus 支付通知状态未知
+var ErrNotificationStatus = NewError(CodeNotificationStatus, "未知的通知状态")
+
+// PaymentNotification GCash 支付结果通知（notifyUrl 回调）
+type PaymentNotification struct {
+	NotificationID string    `json:"notificationId"`
+	OrderID        string    `json:"orderId"`
+	Status         string    `json:"status"` // SUCCESS / FAILED / PENDING
+	Amount         string    `json:"amount,omitempty"`
+	TransactionID  string    `json:"transactionId,omitempty"`
+	Timestamp      time.Time `json:"timestamp,omitempty"`
+}
+
+// notificationStatuses 通知状态 → 订单状态
+var notificationStatuses = map[string]OrderStatus{
+	"SUCCESS":    OrderPaid,
+	"PAID":       OrderPaid,
+	"FAILED":     OrderFailed,
+	"CLOSED":     OrderFailed,
+	"PENDING":    OrderPending,
+	"PROCESSING": OrderPending,
+}
+
+// OrderStatus 将通知状态映射为订单状态
+func (n PaymentNotification) OrderStatus() (OrderStatus, error) {
+	if s, ok := notificationStatuses[strings.ToUpper(n.Status)]; ok {
+		return s, nil
+	}
+	return "", ErrNotificationStatus.Errorf(Params{"status": n.Status}, "%q", n.Status)
+}
+
+// Key 幂等键：优先使用通知 ID，否则按交易号 + 状态
+func (n PaymentNotification) Key() string {
+	if n.NotificationID != "" {
+		return n.NotificationID
+	}
+	return n.TransactionID + "/" + strings.ToUpper(n.Status)
+}
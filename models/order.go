@@ -0,0 +1,172 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// 订单相关错误，可通过 errors.Is 判断
+var (
+	ErrOrderNotFound     = NewError(CodeOrderNotFound, "订单不存在")
+	ErrOrderExists       = NewError(CodeOrderExists, "订单已存在且已进入支付流程")
+	ErrOrderExpired      = NewError(CodeOrderExpired, "订单已过期")
+	ErrOrderClosed       = NewError(CodeOrderClosed, "订单已完成，不能再打开支付链接")
+	ErrInvalidTransition = NewError(CodeOrderInvalidTransition, "订单状态转换无效")
+)
+
+// OrderRegistry 登记带订单号的生成结果，供支付通知匹配
+// 登记失败（如订单已进入支付流程）时生成失败，不写审计记录、不发布事件
+// commit 非空时在冲突检查通过后、登记前调用（如写审计记录），返回错误则不登记订单
+type OrderRegistry interface {
+	SaveWith(result *DeepLinkResult, commit func() error) (*Order, error)
+}
+
+// OrderStatus 订单状态
+//
+//	created ──→ link_opened ──→ pending ──→ paid
+//	   │             │             └──────→ failed
+//	   └─────────────┴──→ expired ──→ paid (逾期到账)
+type OrderStatus string
+
+const (
+	OrderCreated    OrderStatus = "created"     // 已生成 Deep Link
+	OrderLinkOpened OrderStatus = "link_opened" // 用户已打开支付链接
+	OrderPending    OrderStatus = "pending"     // 支付处理中
+	OrderPaid       OrderStatus = "paid"        // 支付成功
+	OrderFailed     OrderStatus = "failed"      // 支付失败
+	OrderExpired    OrderStatus = "expired"     // 超时未支付
+)
+
+// orderTransitions 允许的状态转换
+// 处理中 (pending) 的订单不会过期；已过期订单仍接受支付成功通知（逾期到账）
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderCreated:    {OrderLinkOpened, OrderPending, OrderPaid, OrderFailed, OrderExpired},
+	OrderLinkOpened: {OrderPending, OrderPaid, OrderFailed, OrderExpired},
+	OrderPending:    {OrderPaid, OrderFailed},
+	OrderExpired:    {OrderPaid},
+}
+
+// orderProgress 状态推进的先后，用于识别迟到的通知（如已支付后才到达的 PENDING）
+var orderProgress = map[OrderStatus]int{
+	OrderCreated:    0,
+	OrderLinkOpened: 1,
+	OrderPending:    2,
+	OrderPaid:       3,
+	OrderFailed:     3,
+	OrderExpired:    3,
+}
+
+// Precedes s 是否早于 other，订单不会从 other 回退到 s
+func (s OrderStatus) Precedes(other OrderStatus) bool {
+	return orderProgress[s] < orderProgress[other]
+}
+
+// Final 是否为终态（不再等待用户操作）
+func (s OrderStatus) Final() bool {
+	return s == OrderPaid || s == OrderFailed || s == OrderExpired
+}
+
+// CanTransition 是否允许从 s 转换到 to
+func (s OrderStatus) CanTransition(to OrderStatus) bool {
+	for _, next := range orderTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// 状态转换来源
+const (
+	SourceGenerate = "generate" // /api/generate 生成链接
+	SourceRedirect = "redirect" // /pay/{orderId} 打开链接
+	SourceNotify   = "notify"   // GCash 支付通知
+	SourceExpiry   = "expiry"   // 超时
+)
+
+// OrderTransition 一次状态转换记录
+type OrderTransition struct {
+	From      OrderStatus `json:"from,omitempty"`
+	To        OrderStatus `json:"to"`
+	At        time.Time   `json:"at"`
+	Source    string      `json:"source"`
+	Reference string      `json:"reference,omitempty"` // 通知 ID 等
+}
+
+// Order 已生成 Deep Link 的订单
+type Order struct {
+	OrderID       string      `json:"orderId"`
+	MerchantID    string      `json:"merchantId,omitempty"`
+	Amount        string      `json:"amount,omitempty"`
+	DeepLink      string      `json:"deepLink,omitempty"`    // 推送的订单事件中不含，见 Event.Redacted
+	RedirectURL   string      `json:"redirectUrl,omitempty"` // 含 {status} 的商户跳转模板
+	Status        OrderStatus `json:"status"`
+	TransactionID string      `json:"transactionId,omitempty"`
+	CreatedAt     time.Time   `json:"createdAt"`
+	UpdatedAt     time.Time   `json:"updatedAt"`
+	ExpiresAt     *time.Time  `json:"expiresAt,omitempty"` // 为空表示不过期
+	// Timestamps 首次进入各状态的时间
+	Timestamps map[OrderStatus]time.Time `json:"timestamps"`
+	History    []OrderTransition         `json:"history"`
+}
+
+// NewOrder 由生成结果创建订单，ttl 内未支付则过期（ttl <= 0 不过期）；结果没有订单号时返回错误
+func NewOrder(result *DeepLinkResult, ttl time.Duration) (*Order, error) {
+	if result == nil || !result.Success || result.Resolved == nil || result.Resolved.OrderID == "" {
+		return nil, errors.New("生成结果缺少订单号")
+	}
+	now := result.GeneratedAt
+	order := &Order{
+		OrderID:     result.Resolved.OrderID,
+		MerchantID:  result.Resolved.MerchantID,
+		Amount:      result.Resolved.OrderAmount,
+		DeepLink:    result.DeepLink,
+		RedirectURL: result.Resolved.MerchantRedirectURL,
+		Status:      OrderCreated,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Timestamps:  map[OrderStatus]time.Time{OrderCreated: now},
+		History:     []OrderTransition{{To: OrderCreated, At: now, Source: SourceGenerate}},
+	}
+	if ttl > 0 {
+		expiresAt := now.Add(ttl)
+		order.ExpiresAt = &expiresAt
+	}
+	return order, nil
+}
+
+// Transition 转换到 to 状态并记录历史
+func (o *Order) Transition(to OrderStatus, source, reference string, at time.Time) error {
+	if !o.Status.CanTransition(to) {
+		return ErrInvalidTransition.Errorf(Params{"orderId": o.OrderID}, "%s → %s (订单 %s)", o.Status, to, o.OrderID)
+	}
+	o.History = append(o.History, OrderTransition{
+		From:      o.Status,
+		To:        to,
+		At:        at,
+		Source:    source,
+		Reference: reference,
+	})
+	o.Status = to
+	o.UpdatedAt = at
+	if _, ok := o.Timestamps[to]; !ok {
+		o.Timestamps[to] = at
+	}
+	return nil
+}
+
+// Overdue 是否已超过有效期且可转为 expired
+func (o *Order) Overdue(now time.Time) bool {
+	return o.ExpiresAt != nil && now.After(*o.ExpiresAt) && o.Status.CanTransition(OrderExpired)
+}
+
+// Clone 深拷贝，供存储层返回快照
+func (o *Order) Clone() *Order {
+	c := *o
+	c.Timestamps = make(map[OrderStatus]time.Time, len(o.Timestamps))
+	for status, at := range o.Timestamps {
+		c.Timestamps[status] = at
+	}
+	c.History = append([]OrderTransition(nil), o.History...)
+	return &c
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// RedactMode 脱敏方式
+type RedactMode string
+
+const (
+	RedactNone RedactMode = "none" // 原样输出
+	RedactMask RedactMode = "mask" // 仅保留末尾 KeepLast 位，如 "*******4567"
+	RedactHash RedactMode = "hash" // SHA-256 前 12 位十六进制，可关联同一值但无法还原
+	RedactOmit RedactMode = "omit" // 不输出
+)
+
+// RedactionPolicy 日志脱敏规则，零值字段使用 DefaultRedactionPolicy 的默认值
+type RedactionPolicy struct {
+	QRPayload     RedactMode `json:"qrPayload,omitempty"`     // QR Code 原始数据，默认 hash
+	AccountNumber RedactMode `json:"accountNumber,omitempty"` // P2P 收款账号，默认 mask
+	MobileNumber  RedactMode `json:"mobileNumber,omitempty"`  // Tag 62-02 手机号，默认 mask
+	KeepLast      int        `json:"keepLast,omitempty"`      // mask 保留的末尾位数，默认 4
+}
+
+// DefaultRedactionPolicy 默认脱敏规则
+func DefaultRedactionPolicy() RedactionPolicy {
+	return RedactionPolicy{
+		QRPayload:     RedactHash,
+		AccountNumber: RedactMask,
+		MobileNumber:  RedactMask,
+		KeepLast:      4,
+	}
+}
+
+// Validate 校验脱敏方式
+func (p RedactionPolicy) Validate() error {
+	for _, mode := range []RedactMode{p.QRPayload, p.AccountNumber, p.MobileNumber} {
+		switch mode {
+		case "", RedactNone, RedactMask, RedactHash, RedactOmit:
+		default:
+			return fmt.Errorf("未知的脱敏方式: %q", mode)
+		}
+	}
+	if p.KeepLast < 0 {
+		return fmt.Errorf("keepLast 不能为负数: %d", p.KeepLast)
+	}
+	return nil
+}
+
+// withDefaults 用默认值填充零值字段
+func (p RedactionPolicy) withDefaults() RedactionPolicy {
+	d := DefaultRedactionPolicy()
+	if p.QRPayload == "" {
+		p.QRPayload = d.QRPayload
+	}
+	if p.AccountNumber == "" {
+		p.AccountNumber = d.AccountNumber
+	}
+	if p.MobileNumber == "" {
+		p.MobileNumber = d.MobileNumber
+	}
+	if p.KeepLast == 0 {
+		p.KeepLast = d.KeepLast
+	}
+	return p
+}
+
+// QR 脱敏 QR Code 原始数据
+func (p RedactionPolicy) QR(s string) string {
+	p = p.withDefaults()
+	return p.apply(p.QRPayload, s)
+}
+
+// Account 脱敏收款账号
+func (p RedactionPolicy) Account(s string) string {
+	p = p.withDefaults()
+	return p.apply(p.AccountNumber, s)
+}
+
+// Mobile 脱敏手机号
+func (p RedactionPolicy) Mobile(s string) string {
+	p = p.withDefaults()
+	return p.apply(p.MobileNumber, s)
+}
+
+// apply 按 mode 脱敏 s
+func (p RedactionPolicy) apply(mode RedactMode, s string) string {
+	if s == "" {
+		return ""
+	}
+	switch mode {
+	case RedactNone:
+		return s
+	case RedactHash:
+		return HashValue(s)[:12]
+	case RedactOmit:
+		return ""
+	default:
+		if len(s) <= p.KeepLast {
+			return strings.Repeat("*", len(s))
+		}
+		return strings.Repeat("*", len(s)-p.KeepLast) + s[len(s)-p.KeepLast:]
+	}
+}
+
+// HashValue SHA-256 十六进制摘要
+func HashValue(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
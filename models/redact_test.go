@@ -0,0 +1,25 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+func TestRedactionPolicy(t *testing.T) {
+	policy := models.RedactionPolicy{QRPayload: models.RedactNone, AccountNumber: models.RedactHash}
+	if policy.QR("000201") != "000201" || policy.Account("0917") != models.HashValue("0917")[:12] || policy.Mobile("0917") != "****" {
+		t.Error("脱敏方式错误")
+	}
+	if err := (models.RedactionPolicy{QRPayload: "blur"}).Validate(); err == nil {
+		t.Error("未知脱敏方式应报错")
+	}
+
+	// 审计记录脱敏返回副本，不修改原记录
+	p2p := models.AuditRecord{DeepLink: "gcash://x", Resolved: &models.ResolvedOptions{
+		DeepLinkOptions: models.DeepLinkOptions{QRCode: "000201"}, AccountNumber: "09171234567", AccountName: "JUAN DELA CRUZ"}}
+	if r := p2p.Redacted(models.RedactionPolicy{}); r.Resolved.QRCode != "" || r.Resolved.AccountNumber != "*******4567" ||
+		r.Resolved.AccountName != "**********CRUZ" || p2p.Resolved.AccountNumber != "09171234567" {
+		t.Errorf("审计记录脱敏错误: %+v", r.Resolved)
+	}
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"slices"
+	"time"
+)
 
 // EMVCoData EMVCo QR Code 解析后的数据
 type EMVCoData struct {
@@ -15,21 +19,78 @@ type EMVCoData struct {
 	MerchantName         string // Tag 59 - 商户名称
 	MerchantCity         string // Tag 60 - 商户城市
 	MerchantCategoryCode string // Tag 52 - 商户分类码 (MCC)
+	MerchantCategory     string // MCC 描述（ISO 18245，未收录时为空）
 
 	// 账户信息
-	ShopID   string // 店铺 ID
-	BankCode string // 银行代码
+	ShopID              string // 店铺 ID
+	BankCode            string // 银行代码 (BIC)
+	BankName            string // 银行名称（来自机构目录，未收录时为空）
+	BankType            string // 机构类型
+	MerchantAccountGUID string // Tag 26-51 子标签 00 - Globally Unique Identifier
+
+	// QR 类型: p2m (商户收款) / p2p (个人转账)
+	QRType QRType
+
+	// P2P 账户信息（QR Ph P2P，BankCode 为收款银行 BIC）
+	AccountNumber string // 收款账号 / 手机号
+	AccountName   string // 收款人姓名 (Tag 59)
+
+	// 全部商户账户模板及识别出的支付网络（QR Ph / PromptPay / DuitNow ...）
+	MerchantAccounts []MerchantAccount
+	Network          string
 
 	// 附加数据
-	OrderID       string // Tag 62-03 - Bill Number (账单号)
-	AcqInfo       string // Tag 62-05 - Reference Label (参考标签)
-	TerminalLabel string // Tag 62-07 - Terminal Label
-	CRC           string // Tag 63 - CRC 校验码
+	AdditionalDataGUID string // Tag 62-00 - Globally Unique Identifier
+	MobileNumber       string // Tag 62-02 - Mobile Number (手机号)
+	OrderID            string // Tag 62-03 - Bill Number (账单号)
+	AcqInfo            string // Tag 62-05 - Reference Label (参考标签)
+	TerminalLabel      string // Tag 62-07 - Terminal Label
+	CRC                string // Tag 63 - CRC 校验码
 
 	// 原始数据
 	RawData string // 原始 QR Code 数据
+
+	// 格式识别
+	FormatDetection *QRFormatDetection // QR 布局自动识别结果 (Parse 时填充)
 }
 
+// QRType QR 类型
+type QRType string
+
+const (
+	QRTypeP2M QRType = "p2m" // 商户收款 (GUID ph.ppmi.p2m)
+	QRTypeP2P QRType = "p2p" // InstaPay 个人转账 (GUID com.p2pqrpay / ph.ppmi.p2p)
+)
+
+// MerchantAccount Tag 26-51 商户账户模板
+type MerchantAccount struct {
+	Tag     string `json:"tag"`
+	GUID    string `json:"guid"`
+	Network string `json:"network,omitempty"` // 识别出的支付网络，未知时为空
+}
+
+// QRFormat QR 布局格式（决定 28-03 与 62-05 的含义）
+type QRFormat string
+
+const (
+	QRFormatAuto   QRFormat = ""       // 未指定，由解析器自动识别
+	QRFormatLegacy QRFormat = "legacy" // 旧格式: 28-03=订单号, 62-05=UID
+	QRFormatNew    QRFormat = "new"    // 新格式: 28-03=UID, 62-05=订单号
+)
+
+// QRFormatDetection QR 布局识别结果
+type QRFormatDetection struct {
+	Format     QRFormat `json:"format"`            // 识别出的布局
+	Confidence float64  `json:"confidence"`        // 置信度 0.5 ~ 1.0
+	Reasons    []string `json:"reasons,omitempty"` // 判定依据
+}
+
+// Tag 01 Point of Initiation Method
+const (
+	InitMethodStatic  = "11" // 静态 QR（可重复使用）
+	InitMethodDynamic = "12" // 动态 QR（一次性，金额固定）
+)
+
 // PaymentType 支付类型
 type PaymentType string
 
@@ -44,36 +105,194 @@ const (
 // DeepLinkOptions GCash Deep Link 生成选项
 type DeepLinkOptions struct {
 	// 必需参数
-	QRCode      string // EMVCo QR Code 数据
-	OrderAmount string // 订单金额
+	QRCode      string `json:"qrCode,omitempty"`      // EMVCo QR Code 数据
+	OrderAmount string `json:"orderAmount,omitempty"` // 订单金额
 
 	// 可选参数
-	MerchantID           string      // 商户 ID (可选)
-	MerchantName         string      // 商户名称 (可选)
-	OrderID              string      // 订单 ID
-	PaymentType          PaymentType // 支付类型
-	RedirectURL          string      // 支付完成后跳转 URL
-	NotifyURL            string      // 服务器回调通知 URL
-	ClientID             string      // 客户端 ID (自动生成)
-	ShopID               string      // 店铺 ID
+	MerchantID   string      `json:"merchantId,omitempty"`   // 商户 ID (可选)
+	MerchantName string      `json:"merchantName,omitempty"` // 商户名称 (可选)
+	OrderID      string      `json:"orderId,omitempty"`      // 订单 ID
+	PaymentType  PaymentType `json:"paymentType,omitempty"`  // 支付类型
+	RedirectURL  string      `json:"redirectUrl,omitempty"`  // 支付完成后跳转 URL
+	NotifyURL    string      `json:"notifyUrl,omitempty"`    // 服务器回调通知 URL
+	ClientID     string      `json:"clientId,omitempty"`     // 客户端 ID (自动生成)
+	ShopID       string      `json:"shopId,omitempty"`       // 店铺 ID
 
 	// 高级选项
-	BizNo        string // 业务单号
-	NewQRFormat  bool   // true=新格式(28-03=UID,62-05=订单号), false=旧格式(默认,28-03=订单号,62-05=UID)
+	BizNo       string   `json:"bizNo,omitempty"`       // 业务单号
+	QRFormat    QRFormat `json:"qrFormat,omitempty"`    // QR 布局: ""=自动识别(默认), "legacy"=旧格式, "new"=新格式
+	NewQRFormat bool     `json:"newQRFormat,omitempty"` // 兼容字段: true 等同 QRFormat="new"
+	Profile     string   `json:"profile,omitempty"`     // 参数布局 profile 名称，空则使用默认 profile
+}
+
+// ResolvedOptions 生成时实际生效的参数
+// 在调用方选项基础上填充默认值，并按 QR 布局确定 shopId / acqInfo
+type ResolvedOptions struct {
+	DeepLinkOptions
+
+	// 来自 QR Code（已按布局换位）
+	QRType               QRType `json:"qrType,omitempty"`
+	AccountNumber        string `json:"accountNumber,omitempty"`
+	AccountName          string `json:"accountName,omitempty"`
+	BankCode             string `json:"bankCode,omitempty"`
+	BillNumber           string `json:"billNumber,omitempty"`
+	AcqInfo              string `json:"acqInfo,omitempty"`
+	TerminalLabel        string `json:"terminalLabel,omitempty"`
+	MerchantCity         string `json:"merchantCity,omitempty"`
+	MerchantCategoryCode string `json:"merchantCategoryCode,omitempty"`
+
+	// MerchantRedirectURL 含 {status} 的商户跳转模板；此时 RedirectURL 指向本服务 /return/{orderId}
+	MerchantRedirectURL string `json:"merchantRedirectUrl,omitempty"`
 }
 
 // DeepLinkResult Deep Link 生成结果
+// Options 为调用方传入选项的副本，Resolved 为实际生效的参数；两者均不会回写到调用方
 type DeepLinkResult struct {
 	Success     bool             `json:"success"`
 	DeepLink    string           `json:"deepLink,omitempty"`
 	ParsedData  *EMVCoData       `json:"parsedData,omitempty"`
 	Options     *DeepLinkOptions `json:"options,omitempty"`
+	Resolved    *ResolvedOptions `json:"resolved,omitempty"`
 	Error       string           `json:"error,omitempty"`
+	ErrorCode   string           `json:"errorCode,omitempty"` // 失败时的错误码（见 errors.go）
+	Input       *ParsedInput     `json:"input,omitempty"`     // HTTP 接口实际解析的数据
 	GeneratedAt time.Time        `json:"generatedAt"`
+
+	// QR 布局: 实际采用的格式，以及自动识别时的识别结果
+	QRFormat        QRFormat           `json:"qrFormat,omitempty"`
+	FormatDetection *QRFormatDetection `json:"formatDetection,omitempty"`
+}
+
+// DecodedDeepLink Deep Link 解码结果
+// Profile 为能还原全部参数的参数布局，Fields 为按该布局还原的模板字段（如 shopId、paymentType）
+type DecodedDeepLink struct {
+	BaseURL    string            `json:"baseUrl"`              // 不含查询参数的链接
+	Params     []DeepLinkParam   `json:"params"`               // 查询参数，按链接中的顺序
+	Profile    string            `json:"profile,omitempty"`    // 匹配的参数布局，空表示没有布局匹配
+	Fields     map[string]string `json:"fields,omitempty"`     // 按布局还原的非空字段
+	ParsedData *EMVCoData        `json:"parsedData,omitempty"` // qrCode 参数的解析结果
+	Error      string            `json:"error,omitempty"`      // qrCode 参数缺失或解析失败的原因
+	ErrorCode  string            `json:"errorCode,omitempty"`
+}
+
+// DeepLinkParam Deep Link 查询参数
+type DeepLinkParam struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Strategy 命名的生成策略
+// Options 作为叠加层覆盖请求中的基础选项，只有非零值字段生效；
+// Omit 列出不从请求继承的选项（JSON 字段名，见 OmittableOptions）
+type Strategy struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Options     DeepLinkOptions `json:"options"`
+	Omit        []string        `json:"omit,omitempty"`
+}
+
+// OmittableOptions 策略可以不继承的请求选项
+// qrCode、orderAmount 与 QR 布局描述 QR Code 本身，始终继承
+var OmittableOptions = []string{
+	"merchantId", "merchantName", "orderId", "paymentType", "redirectUrl",
+	"notifyUrl", "clientId", "shopId", "bizNo", "profile",
+}
+
+// Validate 校验 Omit 中的字段名
+func (s Strategy) Validate() error {
+	for _, field := range s.Omit {
+		if !slices.Contains(OmittableOptions, field) {
+			return fmt.Errorf("策略 %s: 不支持省略 %q", s.Name, field)
+		}
+	}
+	return nil
+}
+
+// Without 返回清空 fields 中各选项后的副本，未知字段名忽略
+func (o DeepLinkOptions) Without(fields []string) DeepLinkOptions {
+	for _, field := range fields {
+		switch field {
+		case "merchantId":
+			o.MerchantID = ""
+		case "merchantName":
+			o.MerchantName = ""
+		case "orderId":
+			o.OrderID = ""
+		case "paymentType":
+			o.PaymentType = ""
+		case "redirectUrl":
+			o.RedirectURL = ""
+		case "notifyUrl":
+			o.NotifyURL = ""
+		case "clientId":
+			o.ClientID = ""
+		case "shopId":
+			o.ShopID = ""
+		case "bizNo":
+			o.BizNo = ""
+		case "profile":
+			o.Profile = ""
+		}
+	}
+	return o
+}
+
+// StrategyResult 单个策略的生成结果
+type StrategyResult struct {
+	Name string `json:"name"`
+	DeepLinkResult
+}
+
+// ParameterProfile Deep Link 参数布局 profile
+// 以声明方式描述 URL 参数与 param3/param5 模板，GCash 调整模板时切换 profile 即可
+type ParameterProfile struct {
+	Name        string           `json:"name"`
+	Version     string           `json:"version,omitempty"`
+	Description string           `json:"description,omitempty"`
+	BaseURL     string           `json:"baseUrl,omitempty"` // Deep Link 基础 URL，空则使用 GCashBaseURL
+	Params      []ParameterField `json:"params"`
+}
+
+// ParameterField 单个 URL 参数
+// Value 为模板，{name} 占位符替换为生效参数（如 {shopId}、{paymentType}）
+type ParameterField struct {
+	Key      string   `json:"key"`
+	Value    string   `json:"value"`
+	Always   bool     `json:"always,omitempty"`   // true 时即使值为空也输出，否则值为空或 "null" 时省略
+	Requires []string `json:"requires,omitempty"` // 依赖的占位符，任一为空则省略该参数
 }
 
 // ValidationResult 验证结果
+// Warnings 不影响 Valid，仅提示潜在问题（如非 PHP 货币）
 type ValidationResult struct {
-	Valid  bool     `json:"valid"`
-	Errors []string `json:"errors,omitempty"`
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors,omitempty"`
+	Codes    []string `json:"codes,omitempty"` // 与 Errors 一一对应的错误码
+	Warnings []string `json:"warnings,omitempty"`
+	// WarningCodes 与 Warnings 一一对应的提示码，WarningParams 为翻译模板参数
+	WarningCodes  []string     `json:"warningCodes,omitempty"`
+	WarningParams []Params     `json:"-"`
+	Input         *ParsedInput `json:"input,omitempty"` // HTTP 接口实际解析的数据
 }
+
+// 验证错误码（ValidationResult.Codes）
+const (
+	ValidationEmpty           = "empty"            // QR Code 为空
+	ValidationFormat          = "format"           // TLV 格式或 CRC 校验失败
+	ValidationCurrencyMissing = "currency_missing" // 缺少 Tag 53
+	ValidationCurrencyInvalid = "currency_invalid" // Tag 53 格式错误
+	ValidationAmountInvalid   = "amount_invalid"   // Tag 54 不符合货币精度
+	ValidationCountryMissing  = "country_missing"  // 缺少 Tag 58
+	ValidationCountryInvalid  = "country_invalid"  // Tag 58 格式错误
+)
+
+// 验证提示码（ValidationResult.WarningCodes）
+const (
+	ValidationCurrencyUnknown     = "currency_unknown"          // Tag 53 货币代码未收录
+	ValidationCurrencyUnsupported = "currency_unsupported"      // 非 PHP
+	ValidationCountryUnknown      = "country_unknown"           // Tag 58 国家代码未收录
+	ValidationCountryUnsupported  = "country_unsupported"       // 非菲律宾
+	ValidationCurrencyMismatch    = "currency_country_mismatch" // 货币与国家本币不一致
+	ValidationNetworkUnsupported  = "network_unsupported"       // 非 QR Ph 网络
+	ValidationAcquirerUnknown     = "acquirer_unknown"          // 收单机构 BIC 未收录
+)
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// 回调 URL 相关错误，可通过 errors.Is 判断
+var (
+	ErrInvalidURL    = NewError(CodeInvalidURL, "URL 格式无效")
+	ErrURLNotAllowed = NewError(CodeURLNotAllowed, "URL 主机不在允许列表中")
+)
+
+// URLPolicy redirectUrl / notifyUrl 校验策略
+// 主机允许列表优先级: 商户 (MerchantID) > 默认；均为空时拒绝所有回调 URL
+// 主机支持通配子域名，如 "*.myshop.com"
+type URLPolicy struct {
+	AllowHTTP bool                `json:"allowHttp,omitempty"` // 允许 http（localhost 始终允许）
+	Hosts     []string            `json:"hosts,omitempty"`
+	Merchants map[string][]string `json:"merchants,omitempty"`
+}
+
+// Check 校验 URL：绝对地址、https、无用户信息、主机在允许列表中
+func (p URLPolicy) Check(raw, merchantID string) error {
+	u, err := url.Parse(raw)
+	if err != nil || !u.IsAbs() || u.Host == "" || u.Opaque != "" {
+		return ErrInvalidURL.Errorf(Params{"url": raw}, "%q", raw)
+	}
+	if u.User != nil {
+		return ErrInvalidURL.Errorf(Params{"url": u.Redacted()}, "不允许包含用户信息 (%s)", u.Redacted())
+	}
+
+	host := strings.ToLower(u.Hostname())
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if !p.AllowHTTP && !isLoopback(host) {
+			return ErrInvalidURL.Errorf(Params{"url": raw}, "仅允许 https (%s)", raw)
+		}
+	default:
+		return ErrInvalidURL.Errorf(Params{"url": raw}, "不支持的协议 %s", u.Scheme)
+	}
+
+	allowed := p.Hosts
+	if hosts, ok := p.Merchants[merchantID]; ok {
+		allowed = hosts
+	}
+	if len(allowed) == 0 {
+		return ErrURLNotAllowed.Errorf(Params{"host": host}, "%s (未配置 urlPolicy 允许列表)", host)
+	}
+	if !hostAllowed(host, allowed) {
+		return ErrURLNotAllowed.Errorf(Params{"host": host}, "%s", host)
+	}
+	return nil
+}
+
+// hostAllowed 主机是否匹配允许列表
+func hostAllowed(host string, patterns []string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if host == pattern {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(pattern, "*."); ok && strings.HasSuffix(host, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLoopback 本机地址
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
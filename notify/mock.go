@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// MockNotifier 本地模拟 GCash 发送签名通知，用于测试与联调
+type MockNotifier struct {
+	URL    string
+	Secret string
+	Header string       // 为空时使用 DefaultSignatureHeader
+	Client *http.Client // 为空时使用 http.DefaultClient
+}
+
+// NewMockNotifier 创建模拟通知器
+func NewMockNotifier(url, secret string) *MockNotifier {
+	return &MockNotifier{URL: url, Secret: secret}
+}
+
+// Notify 发送通知；Timestamp 为空时使用当前时间
+func (m *MockNotifier) Notify(n models.PaymentNotification) (*http.Response, error) {
+	if n.Timestamp.IsZero() {
+		n.Timestamp = time.Now().UTC()
+	}
+	body, err := json.Marshal(n)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, m.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	header := m.Header
+	if header == "" {
+		header = DefaultSignatureHeader
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(header, Sign(m.Secret, body))
+
+	client := m.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
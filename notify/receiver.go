@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/qinyuanmao/gcash-deeplink/httperr"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	"github.com/qinyuanmao/gcash-deeplink/store"
+)
+
+// maxNotificationBytes 通知请求体上限
+const maxNotificationBytes = 64 << 10
+
+// Receiver 处理 GCash notifyUrl 回调：校验签名 → 按订单号匹配 → 记录状态转换
+// 重复通知返回 200 且 duplicate=true，GCash 重试时不会重复转换状态
+type Receiver struct {
+	Verifier Verifier
+	Orders   *store.OrderStore
+}
+
+// NewReceiver 创建通知接收器
+func NewReceiver(verifier Verifier, orders *store.OrderStore) *Receiver {
+	return &Receiver{Verifier: verifier, Orders: orders}
+}
+
+// ServeHTTP 实现 http.Handler
+// 错误响应与其他接口一致：带错误码，消息按 Accept-Language 渲染（见 httperr）
+func (rc *Receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httperr.MethodNotAllowed(w, r, http.MethodPost)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxNotificationBytes+1))
+	var maxErr *http.MaxBytesError
+	if len(body) > maxNotificationBytes || errors.As(err, &maxErr) {
+		httperr.Error(w, r, http.StatusRequestEntityTooLarge, &models.Error{
+			Code:    models.CodePayloadTooLarge,
+			Message: "通知内容过大",
+			Params:  models.Params{"limit": strconv.Itoa(maxNotificationBytes)},
+		})
+		return
+	}
+	if err != nil {
+		httperr.Write(w, r, http.StatusBadRequest, models.CodeInvalidRequest, "无法读取通知内容")
+		return
+	}
+	if err := rc.Verifier.Verify(r.Header, body); err != nil {
+		var e *models.Error
+		if !errors.As(err, &e) {
+			err = ErrInvalidSignature.Errorf(nil, "%w", err)
+		}
+		httperr.Error(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	var n models.PaymentNotification
+	if err := json.Unmarshal(body, &n); err != nil {
+		httperr.Write(w, r, http.StatusBadRequest, models.CodeInvalidJSON, "无效的 JSON")
+		return
+	}
+	if n.OrderID == "" {
+		httperr.Error(w, r, http.StatusBadRequest, &models.Error{
+			Code:    models.CodeInvalidRequest,
+			Message: "orderId 不能为空",
+			Params:  models.Params{"field": "orderId"},
+		})
+		return
+	}
+
+	order, duplicate, err := rc.Orders.ApplyNotification(n)
+	if err != nil {
+		httperr.Error(w, r, statusFor(err), err)
+		return
+	}
+
+	httperr.JSON(w, http.StatusOK, map[string]interface{}{
+		"success":   true,
+		"duplicate": duplicate,
+		"order":     order,
+	})
+}
+
+// statusFor 错误对应的 HTTP 状态码
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, models.ErrOrderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrInvalidTransition):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,158 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/generator"
+	"github.com/qinyuanmao/gcash-deeplink/i18n"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	"github.com/qinyuanmao/gcash-deeplink/store"
+)
+
+func TestReceiverErrors(t *testing.T) {
+	rc := NewReceiver(NewHMACVerifier("secret"), store.NewOrderStore())
+	send := func(body, signature, lang string) (int, map[string]string) {
+		req := httptest.NewRequest(http.MethodPost, "/api/gcash/notify", strings.NewReader(body))
+		if signature != "" {
+			req.Header.Set(DefaultSignatureHeader, signature)
+		}
+		req.Header.Set("Accept-Language", lang)
+		rec := httptest.NewRecorder()
+		rc.ServeHTTP(rec, req)
+		var resp map[string]interface{}
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		msg, _ := resp["error"].(string)
+		code, _ := resp["code"].(string)
+		return rec.Code, map[string]string{"error": msg, "code": code}
+	}
+
+	tests := []struct {
+		name   string
+		body   string
+		signed bool
+		status int
+		code   string
+		en     string
+	}{
+		{"签名无效", `{"orderId":"O-1"}`, false, http.StatusUnauthorized, "invalid_signature", "Invalid notification signature"},
+		{"缺少订单号", `{"status":"SUCCESS"}`, true, http.StatusBadRequest, "invalid_request", "orderId is required"},
+		{"未知状态", `{"orderId":"O-1","status":"REFUNDED"}`, true, http.StatusBadRequest, "notification_status", "Unknown notification status: REFUNDED"},
+		{"订单不存在", `{"orderId":"O-1","status":"SUCCESS"}`, true, http.StatusNotFound, "order_not_found", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature := "00"
+			if tt.signed {
+				signature = Sign("secret", []byte(tt.body))
+			}
+			status, resp := send(tt.body, signature, "zh")
+			if status != tt.status || resp["code"] != tt.code || resp["error"] == "" {
+				t.Fatalf("got %d %v, want %d %s", status, resp, tt.status, tt.code)
+			}
+			if tt.en == "" {
+				return
+			}
+			if _, resp := send(tt.body, signature, "en"); resp["error"] != tt.en {
+				t.Errorf("英文消息: got %q, want %q", resp["error"], tt.en)
+			}
+		})
+	}
+}
+
+func TestPaymentNotify(t *testing.T) {
+	orders := store.NewOrderStore()
+	server := httptest.NewServer(NewReceiver(NewHMACVerifier("s3cret"), orders))
+	defer server.Close()
+
+	g := generator.NewDeepLinkGenerator()
+	result, err := g.GenerateWithValidation("00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275",
+		&models.DeepLinkOptions{OrderID: "ORDER-N1"})
+	if err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	if _, err := orders.Save(result); err != nil {
+		t.Fatalf("保存订单失败: %v", err)
+	}
+
+	send := func(m *MockNotifier, n models.PaymentNotification) (int, map[string]interface{}) {
+		t.Helper()
+		resp, err := m.Notify(n)
+		if err != nil {
+			t.Fatalf("发送通知失败: %v", err)
+		}
+		defer resp.Body.Close()
+		var body map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&body)
+		return resp.StatusCode, body
+	}
+	notifier := NewMockNotifier(server.URL, "s3cret")
+	paid := models.PaymentNotification{NotificationID: "N-1", OrderID: "ORDER-N1", Status: "SUCCESS", Amount: "100", TransactionID: "TX-1"}
+
+	// 签名错误
+	if code, _ := send(NewMockNotifier(server.URL, "wrong"), paid); code != http.StatusUnauthorized {
+		t.Errorf("签名错误应返回 401, got %d", code)
+	}
+
+	// 金额不一致
+	mismatch := paid
+	mismatch.Amount = "99.99"
+	if code, _ := send(notifier, mismatch); code != http.StatusBadRequest {
+		t.Errorf("金额不一致应返回 400, got %d", code)
+	}
+	if _, _, err := orders.ApplyNotification(mismatch); !errors.Is(err, models.ErrAmountMismatch) {
+		t.Errorf("金额不一致: %v", err)
+	} else if _, msg := i18n.Render(err, i18n.LangEN); msg != "Amount 99.99 does not match the QR code amount 100.00" {
+		t.Errorf("英文消息应包含金额: %s", msg)
+	}
+
+	// 支付成功，重复通知幂等
+	if code, body := send(notifier, paid); code != http.StatusOK || body["duplicate"] != false {
+		t.Fatalf("支付通知处理失败: %d %v", code, body)
+	}
+	if code, body := send(notifier, paid); code != http.StatusOK || body["duplicate"] != true {
+		t.Errorf("重复通知应幂等返回 duplicate=true: %d %v", code, body)
+	}
+
+	order, err := orders.Get("ORDER-N1")
+	if err != nil {
+		t.Fatalf("订单不存在: %v", err)
+	}
+	if order.Status != models.OrderPaid || order.TransactionID != "TX-1" || len(order.History) != 2 {
+		t.Errorf("订单状态错误: %+v", order)
+	}
+
+	// 迟到的 PENDING 不回退订单状态，幂等返回 200
+	late := models.PaymentNotification{NotificationID: "N-0", OrderID: "ORDER-N1", Status: "PENDING"}
+	if code, body := send(notifier, late); code != http.StatusOK || body["duplicate"] != true {
+		t.Errorf("迟到的 PENDING 应返回 200 duplicate=true: %d %v", code, body)
+	}
+	if order, _ := orders.Get("ORDER-N1"); order.Status != models.OrderPaid {
+		t.Errorf("订单状态不应回退: %s", order.Status)
+	}
+
+	// 终态不可再转换；未知订单
+	failed := models.PaymentNotification{NotificationID: "N-2", OrderID: "ORDER-N1", Status: "FAILED"}
+	if code, _ := send(notifier, failed); code != http.StatusConflict {
+		t.Errorf("已支付订单不应转为失败, got %d", code)
+	}
+	failed.OrderID = "ORDER-UNKNOWN"
+	if code, _ := send(notifier, failed); code != http.StatusNotFound {
+		t.Errorf("未知订单应返回 404, got %d", code)
+	}
+
+	// 已进入支付流程的订单不能重新生成链接，且不留下审计记录
+	audited := 0
+	g.SetOrderRegistry(orders)
+	g.SetAuditSink(models.AuditSinkFunc(func(models.AuditRecord) error { audited++; return nil }))
+	if _, err := g.GenerateWithValidation(result.Resolved.QRCode, &models.DeepLinkOptions{OrderID: "ORDER-N1"}); !errors.Is(err, models.ErrOrderExists) {
+		t.Errorf("已支付订单重新生成应失败, got %v", err)
+	}
+	if audited != 0 {
+		t.Errorf("订单冲突不应写审计记录, got %d", audited)
+	}
+}
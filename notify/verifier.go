@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// ErrInvalidSignature 通知签名校验失败
+var ErrInvalidSignature = models.NewError(models.CodeInvalidSignature, "通知签名无效")
+
+// DefaultSignatureHeader 默认签名请求头
+const DefaultSignatureHeader = "X-GCash-Signature"
+
+// Verifier 通知签名校验器，body 为原始请求体
+type Verifier interface {
+	Verify(header http.Header, body []byte) error
+}
+
+// VerifierFunc 函数适配 Verifier
+type VerifierFunc func(header http.Header, body []byte) error
+
+// Verify 实现 Verifier
+func (f VerifierFunc) Verify(header http.Header, body []byte) error {
+	return f(header, body)
+}
+
+// HMACVerifier HMAC-SHA256 签名校验，签名为请求体摘要的十六进制字符串
+type HMACVerifier struct {
+	Secret []byte
+	Header string // 为空时使用 DefaultSignatureHeader
+}
+
+// NewHMACVerifier 创建 HMAC 校验器
+func NewHMACVerifier(secret string) *HMACVerifier {
+	return &HMACVerifier{Secret: []byte(secret), Header: DefaultSignatureHeader}
+}
+
+// Verify 实现 Verifier
+func (v *HMACVerifier) Verify(header http.Header, body []byte) error {
+	name := v.Header
+	if name == "" {
+		name = DefaultSignatureHeader
+	}
+	got, err := hex.DecodeString(header.Get(name))
+	if err != nil || len(got) == 0 {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal(got, sign(v.Secret, body)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Sign 计算请求体签名（十六进制）
+func Sign(secret string, body []byte) string {
+	return hex.EncodeToString(sign([]byte(secret), body))
+}
+
+func sign(secret, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
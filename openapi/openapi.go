@@ -0,0 +1,252 @@
+// Package openapi 由接口列表与 Go 类型（反射 json 标签）生成 OpenAPI 3.0 文档
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version 生成的 OpenAPI 版本
+const Version = "3.0.3"
+
+// Info 文档信息
+type Info struct {
+	Title       string
+	Version     string
+	Description string
+}
+
+// Operation 一个接口操作
+type Operation struct {
+	Method   string
+	Path     string // 路径模板，如 /v1/orders/{orderId}
+	ID       string // operationId，供客户端生成器命名方法
+	Summary  string
+	Tag      string
+	Scope    string // 所需 API Key scope，空表示无需认证
+	Params   []Parameter
+	Request  interface{} // 请求体类型的零值，nil 表示无请求体
+	Response interface{} // 成功响应类型的零值
+	Status   int         // 成功状态码，默认 200
+	Errors   []int       // 可能返回的错误状态码
+}
+
+// Parameter 路径或查询参数（均为字符串）
+type Parameter struct {
+	Name        string
+	In          string // path / query
+	Description string
+	Required    bool
+}
+
+// Document OpenAPI 文档（JSON 对象）
+type Document map[string]interface{}
+
+// Build 生成文档；errorResponse 为所有错误响应共用的类型
+func Build(info Info, ops []Operation, errorResponse interface{}) Document {
+	s := &schemas{defs: map[string]interface{}{}}
+	errorSchema := s.of(reflect.TypeOf(errorResponse))
+
+	paths := map[string]interface{}{}
+	for _, op := range ops {
+		item, _ := paths[op.Path].(map[string]interface{})
+		if item == nil {
+			item = map[string]interface{}{}
+			paths[op.Path] = item
+		}
+		item[strings.ToLower(op.Method)] = s.operation(op, errorSchema)
+	}
+
+	return Document{
+		"openapi": Version,
+		"info": map[string]interface{}{
+			"title":       info.Title,
+			"version":     info.Version,
+			"description": info.Description,
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": s.defs,
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{"type": "http", "scheme": "bearer"},
+				"apiKeyAuth": map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+			},
+		},
+	}
+}
+
+// operation 单个操作对象
+func (s *schemas) operation(op Operation, errorSchema map[string]interface{}) map[string]interface{} {
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	responses := map[string]interface{}{
+		strconv.Itoa(status): jsonResponse(http.StatusText(status), s.of(reflect.TypeOf(op.Response))),
+	}
+	for _, code := range op.Errors {
+		responses[strconv.Itoa(code)] = jsonResponse(http.StatusText(code), errorSchema)
+	}
+
+	o := map[string]interface{}{
+		"operationId": op.ID,
+		"summary":     op.Summary,
+		"responses":   responses,
+	}
+	if op.Tag != "" {
+		o["tags"] = []string{op.Tag}
+	}
+	if len(op.Params) > 0 {
+		params := make([]interface{}, len(op.Params))
+		for i, p := range op.Params {
+			params[i] = map[string]interface{}{
+				"name":        p.Name,
+				"in":          p.In,
+				"description": p.Description,
+				"required":    p.Required || p.In == "path",
+				"schema":      map[string]interface{}{"type": "string"},
+			}
+		}
+		o["parameters"] = params
+	}
+	if op.Request != nil {
+		o["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": s.of(reflect.TypeOf(op.Request))},
+			},
+		}
+	}
+	if op.Scope != "" {
+		o["description"] = "需要 API Key scope: " + op.Scope
+		o["security"] = []interface{}{
+			map[string]interface{}{"bearerAuth": []string{}},
+			map[string]interface{}{"apiKeyAuth": []string{}},
+		}
+	} else {
+		o["security"] = []interface{}{}
+	}
+	return o
+}
+
+func jsonResponse(description string, schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemas 已生成的命名结构体 schema（components/schemas）
+type schemas struct {
+	defs map[string]interface{}
+}
+
+// of 类型的 schema；命名结构体生成到 components 并返回 $ref
+func (s *schemas) of(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		if _, ok := s.defs[t.Name()]; !ok {
+			s.defs[t.Name()] = map[string]interface{}{} // 占位，避免递归类型无限展开
+			s.defs[t.Name()] = s.object(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Int64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": s.of(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": s.of(t.Elem())}
+	default:
+		return map[string]interface{}{} // interface{} 等任意值
+	}
+}
+
+// object 结构体 schema：按 json 标签命名，没有 omitempty 的字段为必填，匿名嵌入字段展开
+func (s *schemas) object(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+	s.fields(t, properties, &required)
+
+	o := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		o["required"] = required
+	}
+	return o
+}
+
+// fields 收集结构体字段；与 encoding/json 一致，外层字段优先于匿名嵌入结构体的同名字段
+func (s *schemas) fields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			embedded = append(embedded, ft)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		properties[name] = s.of(f.Type)
+		if !strings.Contains(opts, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+
+	for _, et := range embedded {
+		inner := map[string]interface{}{}
+		var innerRequired []string
+		s.fields(et, inner, &innerRequired)
+		for _, name := range innerRequired {
+			if _, shadowed := properties[name]; !shadowed {
+				*required = append(*required, name)
+			}
+		}
+		for name, schema := range inner {
+			if _, shadowed := properties[name]; !shadowed {
+				properties[name] = schema
+			}
+		}
+	}
+}
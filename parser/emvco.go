@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.mercari.io/go-emv-code/mpm"
 	"go.mercari.io/go-emv-code/tlv"
@@ -12,39 +13,74 @@ import (
 )
 
 // EMVCoParser EMVCo QR Code 解析器
-type EMVCoParser struct{}
+type EMVCoParser struct {
+	banks   *models.BankDirectory // BIC 机构目录
+	metrics models.MetricsHook    // 指标回调（可选）
+}
 
-// NewEMVCoParser 创建解析器实例
+// NewEMVCoParser 创建解析器实例（使用内置机构目录）
 func NewEMVCoParser() *EMVCoParser {
-	return &EMVCoParser{}
+	return &EMVCoParser{banks: models.DefaultBankDirectory()}
 }
 
 // merchantAccountSub Tag 26-51 子标签结构
 type merchantAccountSub struct {
-	GlobalUID string `emv:"00"`
-	BankCode  string `emv:"01"`
-	ShopID    string `emv:"03"`
+	GlobalUID     string `emv:"00"`
+	BankCode      string `emv:"01"`
+	ShopID        string `emv:"03"`
+	AccountNumber string `emv:"04"` // P2P 收款账号
 }
 
+// p2pGUIDs QR Ph P2P (InstaPay 个人转账) 的 GUID
+var p2pGUIDs = []string{"com.p2pqrpay", "ph.ppmi.p2p"}
+
 // additionalDataSub Tag 62 子标签结构
 type additionalDataSub struct {
+	GlobalUID     string `emv:"00"`
+	MobileNumber  string `emv:"02"`
 	OrderID       string `emv:"03"`
 	AcqInfo       string `emv:"05"`
 	TerminalLabel string `emv:"07"`
 }
 
+// SetMetrics 设置指标回调，Parse / Validate 完成时调用
+func (p *EMVCoParser) SetMetrics(hook models.MetricsHook) {
+	p.metrics = hook
+}
+
 // Parse 解析 EMVCo QR Code
 // 优先使用 mercari mpm.Decode（含 CRC 校验），失败时回退到宽松 TLV 解析（跳过 CRC）
 // GCash 后端会自行校验 QR 码，CRC 错误不应阻断 deeplink 生成
 func (p *EMVCoParser) Parse(qrData string) (*models.EMVCoData, error) {
+	start := time.Now()
+	data, outcome, err := p.parse(qrData)
+	if p.metrics != nil {
+		p.metrics.ObserveParse(models.ParseEvent{
+			Input:    qrData,
+			Data:     data,
+			Outcome:  outcome,
+			Err:      err,
+			Duration: time.Since(start),
+		})
+	}
+	return data, err
+}
+
+// parse 解析并返回结果分类（不触发指标回调）
+func (p *EMVCoParser) parse(qrData string) (*models.EMVCoData, models.ParseOutcome, error) {
 	if qrData == "" {
-		return nil, fmt.Errorf("QR Code 数据不能为空")
+		return nil, models.ParseFailed, models.ErrQREmpty
 	}
 
 	code, err := mpm.Decode([]byte(qrData))
 	if err != nil {
 		// 严格模式失败（CRC 错误等），回退到宽松 TLV 解析
-		return parseFallback(qrData)
+		data, err := parseFallback(qrData)
+		if err != nil {
+			return nil, models.ParseFailed, err
+		}
+		p.finish(data)
+		return data, models.ParseFallback, nil
 	}
 
 	data := &models.EMVCoData{
@@ -69,7 +105,24 @@ func (p *EMVCoParser) Parse(qrData string) (*models.EMVCoData, error) {
 	// Tag 62 Additional Data — 解析子标签
 	parseAdditionalSubTags(code.AdditionalDataFieldTemplate, data)
 
-	return data, nil
+	p.finish(data)
+
+	return data, models.ParseStrict, nil
+}
+
+// finish 填充依赖多个标签的派生字段
+func (p *EMVCoParser) finish(data *models.EMVCoData) {
+	if data.QRType == models.QRTypeP2P {
+		data.AccountName = data.MerchantName
+	}
+	if bank, ok := p.banks.Lookup(data.BankCode); ok {
+		data.BankName = bank.Name
+		data.BankType = bank.Type
+	}
+	if category, ok := models.LookupMCC(data.MerchantCategoryCode); ok {
+		data.MerchantCategory = category.Description
+	}
+	data.FormatDetection = p.DetectFormat(data)
 }
 
 // parseFallback 宽松 TLV 解析 — 跳过 CRC 校验，直接提取字段
@@ -80,7 +133,7 @@ func parseFallback(qrData string) (*models.EMVCoData, error) {
 		tag := qrData[i : i+2]
 		length, err := strconv.Atoi(qrData[i+2 : i+4])
 		if err != nil || length < 0 {
-			return nil, fmt.Errorf("TLV 解析失败: 位置 %d 长度无效", i+2)
+			return nil, models.ErrQRMalformed.Errorf(models.Params{"position": strconv.Itoa(i + 2)}, "位置 %d 长度无效", i+2)
 		}
 		if i+4+length > len(qrData) {
 			break
@@ -112,7 +165,7 @@ func parseFallback(qrData string) (*models.EMVCoData, error) {
 			// Tag 02-51: Merchant Account Information
 			tagNum, _ := strconv.Atoi(tag)
 			if tagNum >= 2 && tagNum <= 51 {
-				parseMerchantSubTagsFallback(value, data)
+				parseMerchantAccount(tag, value, data)
 			}
 		}
 		i += 4 + length
@@ -120,25 +173,22 @@ func parseFallback(qrData string) (*models.EMVCoData, error) {
 	return data, nil
 }
 
-// parseMerchantSubTagsFallback 从原始 TLV value 中解析 merchant 子标签
-func parseMerchantSubTagsFallback(value string, data *models.EMVCoData) {
-	if data.BankCode != "" {
-		return // 已找到 ph.ppmi.p2m 的 merchant account
-	}
-	var sub merchantAccountSub
-	_ = tlv.NewDecoder(strings.NewReader(value), "emv", 512, 2, 2, nil).Decode(&sub)
-	if strings.Contains(sub.GlobalUID, "ph.ppmi.p2m") {
-		data.BankCode = sub.BankCode
-		data.ShopID = sub.ShopID
+// Validate 验证 EMVCo QR Code（mpm.Decode 自带 CRC 校验和格式验证）
+// 格式通过后再校验货币、国家与支付网络
+func (p *EMVCoParser) Validate(qrData string) *models.ValidationResult {
+	result := p.validate(qrData)
+	if p.metrics != nil {
+		p.metrics.ObserveValidation(result)
 	}
+	return result
 }
 
-// Validate 验证 EMVCo QR Code（mpm.Decode 自带 CRC 校验和格式验证）
-func (p *EMVCoParser) Validate(qrData string) *models.ValidationResult {
+func (p *EMVCoParser) validate(qrData string) *models.ValidationResult {
 	if qrData == "" {
 		return &models.ValidationResult{
 			Valid:  false,
 			Errors: []string{"QR Code 数据不能为空"},
+			Codes:  []string{models.ValidationEmpty},
 		}
 	}
 
@@ -147,23 +197,72 @@ func (p *EMVCoParser) Validate(qrData string) *models.ValidationResult {
 		return &models.ValidationResult{
 			Valid:  false,
 			Errors: []string{err.Error()},
+			Codes:  []string{models.ValidationFormat},
 		}
 	}
-	return &models.ValidationResult{Valid: true}
+
+	result := &models.ValidationResult{Valid: true}
+	if data, _, err := p.parse(qrData); err == nil {
+		p.validateData(data, result)
+	}
+	return result
 }
 
 // parseMerchantSubTags 从 MerchantAccountInformation 中解析子标签
 func parseMerchantSubTags(code *mpm.Code, data *models.EMVCoData) {
 	for _, t := range code.MerchantAccountInformation {
-		var sub merchantAccountSub
-		_ = tlv.NewDecoder(strings.NewReader(t.Value), "emv", 512, 2, 2, nil).Decode(&sub)
-		// 只取包含 ph.ppmi.p2m 的 merchant account
-		if strings.Contains(sub.GlobalUID, "ph.ppmi.p2m") {
-			data.BankCode = sub.BankCode
-			data.ShopID = sub.ShopID
-			return
+		parseMerchantAccount(t.Tag, t.Value, data)
+	}
+}
+
+// parseMerchantAccount 解析单个 Tag 02-51 商户账户
+// 记录 Tag 26-51 模板的 GUID 与所属网络；BankCode / ShopID 只取第一个 ph.ppmi.p2m 账户，
+// 没有 P2M 账户时取第一个 P2P 账户的 BIC 与账号
+func parseMerchantAccount(tag, value string, data *models.EMVCoData) {
+	var sub merchantAccountSub
+	_ = tlv.NewDecoder(strings.NewReader(value), "emv", 512, 2, 2, nil).Decode(&sub)
+
+	if tagNum, _ := strconv.Atoi(tag); tagNum >= 26 && sub.GlobalUID != "" {
+		account := models.MerchantAccount{Tag: tag, GUID: sub.GlobalUID}
+		if n, ok := models.LookupNetwork(sub.GlobalUID); ok {
+			account.Network = n.Name
+			if data.Network == "" || n.Name == models.NetworkQRPh {
+				data.Network = n.Name
+			}
+		}
+		data.MerchantAccounts = append(data.MerchantAccounts, account)
+	}
+
+	if data.QRType == models.QRTypeP2M {
+		return // 已找到 ph.ppmi.p2m 的 merchant account
+	}
+	if strings.Contains(sub.GlobalUID, "ph.ppmi.p2m") {
+		data.QRType = models.QRTypeP2M
+		data.MerchantAccountGUID = sub.GlobalUID
+		data.BankCode = sub.BankCode
+		data.ShopID = sub.ShopID
+		data.AccountNumber = ""
+		return
+	}
+	if data.QRType == "" && isP2PGUID(sub.GlobalUID) {
+		data.QRType = models.QRTypeP2P
+		data.MerchantAccountGUID = sub.GlobalUID
+		data.BankCode = sub.BankCode
+		data.AccountNumber = sub.AccountNumber
+		if data.AccountNumber == "" {
+			data.AccountNumber = sub.ShopID
+		}
+	}
+}
+
+// isP2PGUID 判断是否为 QR Ph P2P 的 GUID
+func isP2PGUID(guid string) bool {
+	for _, g := range p2pGUIDs {
+		if strings.EqualFold(guid, g) {
+			return true
 		}
 	}
+	return false
 }
 
 // parseAdditionalSubTags 从 AdditionalDataFieldTemplate 中解析子标签
@@ -173,6 +272,8 @@ func parseAdditionalSubTags(template string, data *models.EMVCoData) {
 	}
 	var sub additionalDataSub
 	_ = tlv.NewDecoder(strings.NewReader(template), "emv", 512, 2, 2, nil).Decode(&sub)
+	data.AdditionalDataGUID = sub.GlobalUID
+	data.MobileNumber = sub.MobileNumber
 	data.OrderID = sub.OrderID
 	data.AcqInfo = sub.AcqInfo
 	data.TerminalLabel = sub.TerminalLabel
@@ -180,20 +281,51 @@ func parseAdditionalSubTags(template string, data *models.EMVCoData) {
 
 // GetSummary 获取 QR Code 摘要信息
 func (p *EMVCoParser) GetSummary(data *models.EMVCoData) string {
+	if data.QRType == models.QRTypeP2P {
+		return fmt.Sprintf(`EMVCo QR Code 信息 (P2P 转账):
+收款人: %s
+城市: %s
+金额: ₱%s
+账号: %s
+银行: %s`,
+			data.AccountName,
+			data.MerchantCity,
+			data.Amount,
+			data.AccountNumber,
+			bankLabel(data),
+		)
+	}
+
 	return fmt.Sprintf(`EMVCo QR Code 信息:
 商户: %s
 城市: %s
 金额: ₱%s
 店铺ID: %s
-银行代码: %s
+银行: %s
 商户分类: %s
 订单号: %s`,
 		data.MerchantName,
 		data.MerchantCity,
 		data.Amount,
 		data.ShopID,
-		data.BankCode,
-		data.MerchantCategoryCode,
+		bankLabel(data),
+		categoryLabel(data),
 		data.OrderID,
 	)
 }
+
+// categoryLabel 商户分类显示，如 "5411 (Grocery Stores and Supermarkets)"
+func categoryLabel(data *models.EMVCoData) string {
+	if data.MerchantCategory == "" {
+		return data.MerchantCategoryCode
+	}
+	return fmt.Sprintf("%s (%s)", data.MerchantCategoryCode, data.MerchantCategory)
+}
+
+// bankLabel 银行显示名，如 "StarPay Corporation (SRCPPHM2XXX)"；未收录时仅显示 BIC
+func bankLabel(data *models.EMVCoData) string {
+	if data.BankName == "" {
+		return data.BankCode
+	}
+	return fmt.Sprintf("%s (%s)", data.BankName, data.BankCode)
+}
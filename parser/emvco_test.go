@@ -0,0 +1,60 @@
+package parser_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	"github.com/qinyuanmao/gcash-deeplink/parser"
+)
+
+func TestBankDirectory(t *testing.T) {
+	p := parser.NewEMVCoParser()
+	data, err := p.Parse("00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275")
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if data.BankName != "StarPay Corporation" || data.BankType != models.ParticipantEMI {
+		t.Errorf("银行信息错误: name=%q, type=%q", data.BankName, data.BankType)
+	}
+	if !strings.Contains(p.GetSummary(data), "StarPay Corporation (SRCPPHM2XXX)") {
+		t.Errorf("摘要应包含银行名称:\n%s", p.GetSummary(data))
+	}
+
+	// 分行 BIC 回退到总行，BIC8 自动补 XXX
+	directory := models.NewBankDirectory([]models.Participant{{BIC: "TESTPHM1", Name: "Test Bank", Type: models.ParticipantRuralBank}})
+	if bank, ok := directory.Lookup("testphm1abc"); !ok || bank.Name != "Test Bank" {
+		t.Errorf("分行 BIC 查找失败: %+v", bank)
+	}
+
+	// 未收录的收单机构给出警告
+	unknown := "00020101021228530011ph.ppmi.p2m0111ZZZZPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC6304"
+	result := p.Validate(unknown + crc16Hex(unknown))
+	if !result.Valid {
+		t.Fatalf("应通过格式验证: %v", result.Errors)
+	}
+	found := false
+	for _, w := range result.Warnings {
+		found = found || strings.Contains(w, "ZZZZPHM2XXX")
+	}
+	if !found {
+		t.Errorf("未知 BIC 应产生警告: %v", result.Warnings)
+	}
+}
+
+// crc16Hex 计算 EMVCo CRC-16/CCITT-FALSE
+func crc16Hex(s string) string {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%04X", crc)
+}
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"math"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// 新版 QR 的 UID / 订单号均为长数字串（如 2082899083478722304）
+const minNumericIDLen = 16
+
+// formatSignal 单条布局判定依据
+type formatSignal struct {
+	format models.QRFormat
+	weight float64
+	reason string
+}
+
+// DetectFormat 启发式识别 QR 布局（旧格式 28-03=订单号,62-05=UID / 新格式 28-03=UID,62-05=订单号）
+// 依据 GUID、子标签是否存在、取值模式和长度打分，得分高者胜出；
+// 无法区分时按旧格式处理（与历史默认行为一致）
+func (p *EMVCoParser) DetectFormat(data *models.EMVCoData) *models.QRFormatDetection {
+	if data == nil {
+		return &models.QRFormatDetection{Format: models.QRFormatLegacy, Confidence: 0.5}
+	}
+
+	var signals []formatSignal
+	add := func(format models.QRFormat, weight float64, reason string) {
+		signals = append(signals, formatSignal{format: format, weight: weight, reason: reason})
+	}
+
+	// 62-00 GUID: 新版 QR 使用 ph.ppmi.p2m，旧版多为 ph.ppmi.qrph / ph.starpay 等
+	switch data.AdditionalDataGUID {
+	case "":
+	case "ph.ppmi.p2m":
+		add(models.QRFormatNew, 3, "Tag 62-00 GUID 为 ph.ppmi.p2m")
+	default:
+		add(models.QRFormatLegacy, 2, "Tag 62-00 GUID 为 "+data.AdditionalDataGUID)
+	}
+
+	// 62-03 Bill Number: 新版 QR 不携带
+	if data.OrderID != "" {
+		add(models.QRFormatLegacy, 2, "存在 Tag 62-03 账单号")
+	}
+
+	// 62-05 为空时无可交换的值
+	if data.AcqInfo == "" {
+		add(models.QRFormatLegacy, 3, "Tag 62-05 为空")
+	} else if isLongNumeric(data.AcqInfo) {
+		add(models.QRFormatNew, 1, "Tag 62-05 为长数字串")
+	} else {
+		add(models.QRFormatLegacy, 1, "Tag 62-05 非长数字串")
+	}
+
+	// 28-03: 新版为长数字 UID，旧版多为字母数字混合的商户号
+	if data.ShopID != "" {
+		if isLongNumeric(data.ShopID) {
+			add(models.QRFormatNew, 2, "Tag 28-03 为长数字串")
+		} else {
+			add(models.QRFormatLegacy, 2, "Tag 28-03 含非数字字符")
+		}
+	}
+
+	// 新版 UID 与订单号长度一致
+	if isLongNumeric(data.ShopID) && isLongNumeric(data.AcqInfo) && len(data.ShopID) == len(data.AcqInfo) {
+		add(models.QRFormatNew, 1, "Tag 28-03 与 62-05 长度一致")
+	}
+
+	var newScore, legacyScore float64
+	for _, s := range signals {
+		if s.format == models.QRFormatNew {
+			newScore += s.weight
+		} else {
+			legacyScore += s.weight
+		}
+	}
+
+	result := &models.QRFormatDetection{Format: models.QRFormatLegacy, Confidence: 0.5}
+	if newScore > legacyScore {
+		result.Format = models.QRFormatNew
+	}
+	if total := newScore + legacyScore; total > 0 {
+		result.Confidence = math.Round(math.Max(newScore, legacyScore)/total*100) / 100
+	}
+	for _, s := range signals {
+		if s.format == result.Format {
+			result.Reasons = append(result.Reasons, s.reason)
+		}
+	}
+	return result
+}
+
+// isLongNumeric 判断是否为长数字串
+func isLongNumeric(s string) bool {
+	if len(s) < minNumericIDLen {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	"github.com/qinyuanmao/gcash-deeplink/parser"
+)
+
+func TestDetectQRFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		qrCode string
+		want   models.QRFormat
+	}{
+		{
+			name:   "旧版 QR (starpay)",
+			qrCode: "00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275",
+			want:   models.QRFormatLegacy,
+		},
+		{
+			name:   "旧版 QR (qrph)",
+			qrCode: "00020101021228790011ph.ppmi.p2m0111PAEYPHM2XXX0324VkHUE2Fz8Ee2YxnTVPX34TZs0410030300288605030105204739953036085406100.005802PH5916NEXA ONLINE SHOP6013General Trias62430012ph.ppmi.qrph0306wWMBdH05062110000803***88440012ph.ppmi.qrph0124VkHUE2Fz8Ee2YxnTVPX34TZs63041C3C",
+			want:   models.QRFormatLegacy,
+		},
+		{
+			name:   "新版 QR",
+			qrCode: "00020101021228600011ph.ppmi.p2m0111DCPHPHM1XXX031920828990834787223040503011520448165303608540410005802PH5909BuzhuYazi6011Baguio city62380011ph.ppmi.p2m051921653329512971919506304EC47",
+			want:   models.QRFormatNew,
+		},
+	}
+
+	p := parser.NewEMVCoParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := p.Parse(tt.qrCode)
+			if err != nil {
+				t.Fatalf("解析失败: %v", err)
+			}
+			if data.FormatDetection == nil {
+				t.Fatal("FormatDetection 不应为空")
+			}
+			if data.FormatDetection.Format != tt.want {
+				t.Errorf("格式错误: got %s, want %s (%v)", data.FormatDetection.Format, tt.want, data.FormatDetection.Reasons)
+			}
+			if data.FormatDetection.Confidence <= 0.5 {
+				t.Errorf("置信度过低: %v", data.FormatDetection.Confidence)
+			}
+		})
+	}
+}
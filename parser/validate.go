@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// validateData 对解析结果做业务校验（货币、国家、支付网络、收单机构），结果追加到 result
+// 格式错误记为 Errors；GCash 无法结算的跨境 QR、未收录的收单机构记为 Warnings
+func (p *EMVCoParser) validateData(data *models.EMVCoData, result *models.ValidationResult) {
+	addError := func(code, format string, args ...interface{}) {
+		result.Valid = false
+		result.Errors = append(result.Errors, fmt.Sprintf(format, args...))
+		result.Codes = append(result.Codes, code)
+	}
+	addWarning := func(code string, params models.Params, format string, args ...interface{}) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(format, args...))
+		result.WarningCodes = append(result.WarningCodes, code)
+		result.WarningParams = append(result.WarningParams, params)
+	}
+
+	// Tag 53 货币 (ISO 4217 数字代码)
+	currency, currencyKnown := models.LookupCurrency(data.Currency)
+	switch {
+	case data.Currency == "":
+		addError(models.ValidationCurrencyMissing, "缺少 Tag 53 货币代码")
+	case len(data.Currency) != 3 || !isNumeric(data.Currency):
+		addError(models.ValidationCurrencyInvalid, "Tag 53 货币代码无效: %s", data.Currency)
+	case !currencyKnown:
+		addWarning(models.ValidationCurrencyUnknown, models.Params{"currency": data.Currency}, "Tag 53 货币代码未收录: %s", data.Currency)
+	case currency.Numeric != models.CurrencyPHP:
+		addWarning(models.ValidationCurrencyUnsupported, models.Params{"currency": currency.String()}, "货币为 %s，GCash 仅支持 PHP 结算", currency)
+	}
+	if currencyKnown && data.Amount != "" {
+		if err := currency.CheckAmount(data.Amount); err != nil {
+			addError(models.ValidationAmountInvalid, "Tag 54 %v", err)
+		}
+	}
+
+	// Tag 58 国家 (ISO 3166-1 alpha-2)
+	country, countryKnown := models.LookupCountry(data.CountryCode)
+	switch {
+	case data.CountryCode == "":
+		addError(models.ValidationCountryMissing, "缺少 Tag 58 国家代码")
+	case len(data.CountryCode) != 2 || !isAlpha(data.CountryCode):
+		addError(models.ValidationCountryInvalid, "Tag 58 国家代码无效: %s", data.CountryCode)
+	case !countryKnown:
+		addWarning(models.ValidationCountryUnknown, models.Params{"country": data.CountryCode}, "Tag 58 国家代码未收录: %s", data.CountryCode)
+	case country.Alpha2 != models.CountryPH:
+		addWarning(models.ValidationCountryUnsupported, models.Params{"country": country.Alpha2}, "国家为 %s (%s)，GCash 仅支持菲律宾 QR Code", country.Name, country.Alpha2)
+	}
+	if currencyKnown && countryKnown && country.Currency != currency.Numeric {
+		addWarning(models.ValidationCurrencyMismatch, models.Params{"currency": currency.String(), "country": country.Alpha2}, "货币 %s 与国家 %s 的本币不一致", currency, country.Alpha2)
+	}
+
+	// 互联网络 (Tag 26-51 GUID)
+	if data.Network != "" && data.Network != models.NetworkQRPh {
+		addWarning(models.ValidationNetworkUnsupported, models.Params{"network": data.Network}, "QR Code 属于 %s 网络，GCash 无法结算", data.Network)
+	}
+
+	// 收单机构 BIC
+	if data.BankCode != "" {
+		if _, ok := p.banks.Lookup(data.BankCode); !ok {
+			addWarning(models.ValidationAcquirerUnknown, models.Params{"bic": data.BankCode}, "未知的收单机构 BIC: %s", data.BankCode)
+		}
+	}
+}
+
+// isNumeric 判断是否全为数字
+func isNumeric(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isAlpha 判断是否全为字母
+func isAlpha(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(c rune) bool {
+		return (c < 'A' || c > 'Z') && (c < 'a' || c > 'z')
+	}) < 0
+}
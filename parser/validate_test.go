@@ -0,0 +1,34 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/qinyuanmao/gcash-deeplink/i18n"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	"github.com/qinyuanmao/gcash-deeplink/parser"
+)
+
+// promptPayQR 泰国 PromptPay 跨境 QR（THB / TH）
+const promptPayQR = "00020101021229370016A0000006770101110113006681234567853037645406100.005802TH5909SIAM SHOP6007Bangkok63043C35"
+
+func TestValidateWarningCodes(t *testing.T) {
+	result := parser.NewEMVCoParser().Validate(promptPayQR)
+	if !result.Valid {
+		t.Fatalf("格式有效的跨境 QR 应通过验证: %v", result.Errors)
+	}
+	want := []string{models.ValidationCurrencyUnsupported, models.ValidationCountryUnsupported, models.ValidationNetworkUnsupported}
+	if len(result.WarningCodes) != len(want) || len(result.Warnings) != len(want) || len(result.WarningParams) != len(want) {
+		t.Fatalf("提示码应与提示一一对应: codes=%v warnings=%v", result.WarningCodes, result.Warnings)
+	}
+	for i, code := range want {
+		if result.WarningCodes[i] != code {
+			t.Errorf("第 %d 条提示码: got %q, want %q", i, result.WarningCodes[i], code)
+		}
+	}
+
+	// 按语言翻译提示，模板参数来自 WarningParams
+	i18n.LocalizeValidation(result, i18n.LangEN)
+	if got := result.Warnings[2]; got != "QR code belongs to the PromptPay network, GCash cannot settle it" {
+		t.Errorf("英文提示错误: %q", got)
+	}
+}
@@ -0,0 +1,291 @@
+// GCash Deep Link gRPC 接口，与 HTTP API 共用 parser / generator
+// 修改后执行 make proto 重新生成 *.pb.go
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: deeplink/v1/deeplink.proto
+
+package deeplinkv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DeepLinkService_Parse_FullMethodName          = "/gcashdeeplink.v1.DeepLinkService/Parse"
+	DeepLinkService_Validate_FullMethodName       = "/gcashdeeplink.v1.DeepLinkService/Validate"
+	DeepLinkService_Generate_FullMethodName       = "/gcashdeeplink.v1.DeepLinkService/Generate"
+	DeepLinkService_GenerateBatch_FullMethodName  = "/gcashdeeplink.v1.DeepLinkService/GenerateBatch"
+	DeepLinkService_DecodeDeepLink_FullMethodName = "/gcashdeeplink.v1.DeepLinkService/DecodeDeepLink"
+)
+
+// DeepLinkServiceClient is the client API for DeepLinkService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// # DeepLinkService 解析 QR Code、生成与解码 GCash Deep Link
+//
+// 错误以 gRPC status 返回，details 中的 google.rpc.ErrorInfo.reason 为稳定错误码（与 HTTP API 的 code 相同）；
+// 元数据 accept-language 决定错误消息的语言，authorization / x-api-key 携带 API Key
+type DeepLinkServiceClient interface {
+	// Parse 解析 EMVCo QR Code（需要 parse scope）
+	Parse(ctx context.Context, in *ParseRequest, opts ...grpc.CallOption) (*ParseResponse, error)
+	// Validate 验证 QR Code，验证失败不返回错误而是 valid = false（需要 parse scope）
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+	// Generate 生成 Deep Link（需要 generate scope）
+	Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
+	// GenerateBatch 批量生成：每收到一个请求返回一个结果，单个失败不会中断流（需要 generate scope）
+	GenerateBatch(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[GenerateRequest, GenerateBatchResponse], error)
+	// DecodeDeepLink 解码 Deep Link，按参数布局还原字段并解析其中的 qrCode（需要 parse scope）
+	DecodeDeepLink(ctx context.Context, in *DecodeDeepLinkRequest, opts ...grpc.CallOption) (*DecodeDeepLinkResponse, error)
+}
+
+type deepLinkServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDeepLinkServiceClient(cc grpc.ClientConnInterface) DeepLinkServiceClient {
+	return &deepLinkServiceClient{cc}
+}
+
+func (c *deepLinkServiceClient) Parse(ctx context.Context, in *ParseRequest, opts ...grpc.CallOption) (*ParseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ParseResponse)
+	err := c.cc.Invoke(ctx, DeepLinkService_Parse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deepLinkServiceClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, DeepLinkService_Validate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deepLinkServiceClient) Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateResponse)
+	err := c.cc.Invoke(ctx, DeepLinkService_Generate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deepLinkServiceClient) GenerateBatch(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[GenerateRequest, GenerateBatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DeepLinkService_ServiceDesc.Streams[0], DeepLinkService_GenerateBatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GenerateRequest, GenerateBatchResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DeepLinkService_GenerateBatchClient = grpc.BidiStreamingClient[GenerateRequest, GenerateBatchResponse]
+
+func (c *deepLinkServiceClient) DecodeDeepLink(ctx context.Context, in *DecodeDeepLinkRequest, opts ...grpc.CallOption) (*DecodeDeepLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DecodeDeepLinkResponse)
+	err := c.cc.Invoke(ctx, DeepLinkService_DecodeDeepLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeepLinkServiceServer is the server API for DeepLinkService service.
+// All implementations must embed UnimplementedDeepLinkServiceServer
+// for forward compatibility.
+//
+// # DeepLinkService 解析 QR Code、生成与解码 GCash Deep Link
+//
+// 错误以 gRPC status 返回，details 中的 google.rpc.ErrorInfo.reason 为稳定错误码（与 HTTP API 的 code 相同）；
+// 元数据 accept-language 决定错误消息的语言，authorization / x-api-key 携带 API Key
+type DeepLinkServiceServer interface {
+	// Parse 解析 EMVCo QR Code（需要 parse scope）
+	Parse(context.Context, *ParseRequest) (*ParseResponse, error)
+	// Validate 验证 QR Code，验证失败不返回错误而是 valid = false（需要 parse scope）
+	Validate(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	// Generate 生成 Deep Link（需要 generate scope）
+	Generate(context.Context, *GenerateRequest) (*GenerateResponse, error)
+	// GenerateBatch 批量生成：每收到一个请求返回一个结果，单个失败不会中断流（需要 generate scope）
+	GenerateBatch(grpc.BidiStreamingServer[GenerateRequest, GenerateBatchResponse]) error
+	// DecodeDeepLink 解码 Deep Link，按参数布局还原字段并解析其中的 qrCode（需要 parse scope）
+	DecodeDeepLink(context.Context, *DecodeDeepLinkRequest) (*DecodeDeepLinkResponse, error)
+	mustEmbedUnimplementedDeepLinkServiceServer()
+}
+
+// UnimplementedDeepLinkServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDeepLinkServiceServer struct{}
+
+func (UnimplementedDeepLinkServiceServer) Parse(context.Context, *ParseRequest) (*ParseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Parse not implemented")
+}
+func (UnimplementedDeepLinkServiceServer) Validate(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedDeepLinkServiceServer) Generate(context.Context, *GenerateRequest) (*GenerateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Generate not implemented")
+}
+func (UnimplementedDeepLinkServiceServer) GenerateBatch(grpc.BidiStreamingServer[GenerateRequest, GenerateBatchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method GenerateBatch not implemented")
+}
+func (UnimplementedDeepLinkServiceServer) DecodeDeepLink(context.Context, *DecodeDeepLinkRequest) (*DecodeDeepLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecodeDeepLink not implemented")
+}
+func (UnimplementedDeepLinkServiceServer) mustEmbedUnimplementedDeepLinkServiceServer() {}
+func (UnimplementedDeepLinkServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeDeepLinkServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DeepLinkServiceServer will
+// result in compilation errors.
+type UnsafeDeepLinkServiceServer interface {
+	mustEmbedUnimplementedDeepLinkServiceServer()
+}
+
+func RegisterDeepLinkServiceServer(s grpc.ServiceRegistrar, srv DeepLinkServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDeepLinkServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DeepLinkService_ServiceDesc, srv)
+}
+
+func _DeepLinkService_Parse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeepLinkServiceServer).Parse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeepLinkService_Parse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeepLinkServiceServer).Parse(ctx, req.(*ParseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeepLinkService_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeepLinkServiceServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeepLinkService_Validate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeepLinkServiceServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeepLinkService_Generate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeepLinkServiceServer).Generate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeepLinkService_Generate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeepLinkServiceServer).Generate(ctx, req.(*GenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeepLinkService_GenerateBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DeepLinkServiceServer).GenerateBatch(&grpc.GenericServerStream[GenerateRequest, GenerateBatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DeepLinkService_GenerateBatchServer = grpc.BidiStreamingServer[GenerateRequest, GenerateBatchResponse]
+
+func _DeepLinkService_DecodeDeepLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecodeDeepLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeepLinkServiceServer).DecodeDeepLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeepLinkService_DecodeDeepLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeepLinkServiceServer).DecodeDeepLink(ctx, req.(*DecodeDeepLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DeepLinkService_ServiceDesc is the grpc.ServiceDesc for DeepLinkService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DeepLinkService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gcashdeeplink.v1.DeepLinkService",
+	HandlerType: (*DeepLinkServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Parse",
+			Handler:    _DeepLinkService_Parse_Handler,
+		},
+		{
+			MethodName: "Validate",
+			Handler:    _DeepLinkService_Validate_Handler,
+		},
+		{
+			MethodName: "Generate",
+			Handler:    _DeepLinkService_Generate_Handler,
+		},
+		{
+			MethodName: "DecodeDeepLink",
+			Handler:    _DeepLinkService_DecodeDeepLink_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GenerateBatch",
+			Handler:       _DeepLinkService_GenerateBatch_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "deeplink/v1/deeplink.proto",
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/qinyuanmao/gcash-deeplink/auth"
+	"github.com/qinyuanmao/gcash-deeplink/logging"
+	"github.com/qinyuanmao/gcash-deeplink/middleware"
+)
+
+// apiServer HTTP API 服务
+// 使用独立的路由（不注册到 DefaultServeMux），ctx 取消时停止接收新连接并等待处理中的请求完成
+type apiServer struct {
+	addr            string
+	certFile        string // 与 keyFile 同时设置时启用 HTTPS
+	keyFile         string
+	shutdownTimeout time.Duration
+	openBrowser     bool // 开始监听后自动打开浏览器
+
+	mux   *http.ServeMux
+	ready atomic.Bool // 开始监听后为 true，退出时置为 false
+}
+
+// newAPIServer 创建 API 服务并注册路由
+func newAPIServer(addr string) *apiServer {
+	s := &apiServer{
+		addr:            addr,
+		shutdownTimeout: 30 * time.Second,
+		mux:             http.NewServeMux(),
+	}
+	s.routes()
+	return s
+}
+
+// routes 注册路由
+func (s *apiServer) routes() {
+	// Web 界面（内置 public/，配置 publicDir 时从该目录读取）
+	s.handle("/", staticHandler(appConfig.PublicDir))
+
+	// API 端点：/api 为原有格式，/v1 为带版本的类型化接口（配置 apiKeysFile 后需要对应 scope 的 API Key）
+	routes := apiRoutes()
+	for _, route := range routes {
+		var h http.Handler = route.handler
+		if route.scope != "" {
+			h = auth.Require(apiKeys, route.scope, h)
+		}
+		s.handle("/api"+route.path, h)
+		s.handle("/v1"+route.path, h)
+	}
+	s.handle("/v1/openapi.json", openAPIHandler(openAPIDocument(routes)))
+
+	// 面向 GCash 与付款用户的端点（签名校验 / 无需认证）
+	s.handle("/api/gcash/notify", notifyHandler())
+	s.handle("/pay/", http.HandlerFunc(handlePay))
+	s.handle("/return/", http.HandlerFunc(handleReturn))
+
+	// 健康检查: /health 为详细信息，/livez 与 /readyz 供负载均衡 / 编排系统探测
+	s.handle("/health", http.HandlerFunc(handleHealth))
+	s.handle("/livez", http.HandlerFunc(s.handleLive))
+	s.handle("/readyz", http.HandlerFunc(s.handleReady))
+
+	// Prometheus 指标（不计入请求统计）
+	s.mux.Handle("/metrics", auth.Require(apiKeys, auth.ScopeMetrics, appMetrics.Handler()))
+}
+
+// handle 注册路由并按路由模式统计请求数与耗时
+func (s *apiServer) handle(pattern string, h http.Handler) {
+	s.mux.Handle(pattern, appMetrics.Instrument(pattern, h))
+}
+
+// tls 是否启用 HTTPS
+func (s *apiServer) tls() bool {
+	return s.certFile != "" && s.keyFile != ""
+}
+
+// URL 访问地址；未指定主机或监听所有地址时使用 localhost
+func (s *apiServer) URL() string {
+	return serverURL(s.addr, s.tls())
+}
+
+func serverURL(addr string, tls bool) string {
+	scheme := "http"
+	if tls {
+		scheme = "https"
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return scheme + "://" + addr
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return scheme + "://" + net.JoinHostPort(host, port)
+}
+
+// Run 监听 addr 并提供服务，直到 ctx 取消或监听失败
+func (s *apiServer) Run(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve 在 ln 上提供服务
+// ctx 取消后标记为未就绪，停止接收新连接，并在 shutdownTimeout 内等待处理中的请求完成
+func (s *apiServer) Serve(ctx context.Context, ln net.Listener) error {
+	s.addr = ln.Addr().String()
+	server, err := newHTTPServer(s.addr, s.mux)
+	if err != nil {
+		ln.Close()
+		return err
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		if s.tls() {
+			errc <- server.ServeTLS(ln, s.certFile, s.keyFile)
+		} else {
+			errc <- server.Serve(ln)
+		}
+	}()
+	s.ready.Store(true)
+	defer s.ready.Store(false)
+
+	// 定期将超时未支付的订单转为 expired
+	go expireOrders(ctx, time.Minute)
+
+	if s.openBrowser {
+		go openBrowser(s.URL())
+	}
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.ready.Store(false)
+	slog.Info("正在关闭 HTTP 服务，等待处理中的请求完成", "timeout", s.shutdownTimeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("关闭 HTTP 服务失败: %w", err)
+	}
+	if err := <-errc; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// handleLive 存活探测：进程能处理请求即返回 200
+func (s *apiServer) handleLive(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"status": "alive",
+	})
+}
+
+// handleReady 就绪探测：正在启动或关闭时返回 503
+func (s *apiServer) handleReady(w http.ResponseWriter, r *http.Request) {
+	if !s.ready.Load() {
+		respondJSON(w, http.StatusServiceUnavailable, map[string]interface{}{
+			"status": "unavailable",
+		})
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"status": "ready",
+	})
+}
+
+// rateLimitExempt 不限流的路径：支付通知来自 GCash 少量固定 IP，已由签名校验保护
+var rateLimitExempt = []string{"/api/gcash/notify"}
+
+// newHTTPServer 创建带防护中间件与超时设置的 HTTP 服务器
+// 中间件顺序: 请求 ID 与访问日志 → 并发上限 → 限流 → 请求体大小 → CORS → 路由
+func newHTTPServer(addr string, mux http.Handler) (*http.Server, error) {
+	limits := appConfig.Limits
+	readHeader, read, write, idle, err := limits.Timeouts()
+	if err != nil {
+		return nil, err
+	}
+
+	var handler http.Handler = enableCORS(mux)
+	handler = middleware.MaxBytes(limits.MaxBodyBytes, handler)
+	handler = middleware.RateLimit(
+		middleware.NewLimiter(limits.IPRate, limits.IPBurst),
+		middleware.NewLimiter(limits.KeyRate, limits.KeyBurst),
+		limits.ProxyHops(), rateLimitExempt, handler)
+	handler = middleware.ConcurrencyLimit(limits.MaxConcurrent, handler)
+	handler = logging.Middleware(appLogger, handler)
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: readHeader,
+		ReadTimeout:       read,
+		WriteTimeout:      write,
+		IdleTimeout:       idle,
+	}, nil
+}
+
+// expireOrders 定期过期超时订单并移除超过保留时间的终态订单，ctx 取消时退出
+func expireOrders(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, order := range orderStore.ExpireOverdue() {
+				slog.Info("订单已过期", "order_id", order.OrderID, "merchant_id", order.MerchantID)
+			}
+			if pruned := orderStore.Prune(); pruned > 0 {
+				slog.Debug("已移除超过保留时间的订单", "count", pruned)
+			}
+		}
+	}
+}
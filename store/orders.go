@@ -0,0 +1,242 @@
+package store
+
+import (
+	"sync"
+	"time"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// DefaultOrderTTL 订单默认有效期
+const DefaultOrderTTL = 15 * time.Minute
+
+// DefaultOrderRetention 终态订单的默认保留时间，超过后由 Prune 移除
+const DefaultOrderRetention = 24 * time.Hour
+
+// OrderStore 内存订单存储，按订单号索引，并发安全
+// 过期在读取与更新时按需检查，也可定期调用 ExpireOverdue 批量处理
+type OrderStore struct {
+	mu        sync.Mutex
+	orders    map[string]*models.Order
+	processed map[string]map[string]bool // 订单号 → 已处理通知的幂等键，随订单一起移除
+	ttl       time.Duration
+	retention time.Duration
+	now       func() time.Time
+	events    models.EventSink // 状态转换事件（可选）
+}
+
+// NewOrderStore 创建订单存储
+func NewOrderStore() *OrderStore {
+	return &OrderStore{
+		orders:    make(map[string]*models.Order),
+		processed: make(map[string]map[string]bool),
+		ttl:       DefaultOrderTTL,
+		retention: DefaultOrderRetention,
+		now:       time.Now,
+	}
+}
+
+// SetTTL 设置新订单有效期（<= 0 不过期），需在开始保存前调用
+func (s *OrderStore) SetTTL(ttl time.Duration) {
+	s.ttl = ttl
+}
+
+// SetRetention 设置终态订单（paid / failed / expired）的保留时间（<= 0 永久保留）
+func (s *OrderStore) SetRetention(retention time.Duration) {
+	s.retention = retention
+}
+
+// SetEventSink 设置事件推送，订单转为 link_opened / paid / failed 时发布对应事件
+func (s *OrderStore) SetEventSink(sink models.EventSink) {
+	s.events = sink
+}
+
+// SetClock 替换时钟（测试用）
+func (s *OrderStore) SetClock(now func() time.Time) {
+	s.now = now
+}
+
+// Save 记录生成结果
+// 同一商户的订单号尚未进入支付流程（created / link_opened / expired）时以新链接覆盖，否则返回 ErrOrderExists
+// 订单号已属于其他商户时始终返回 ErrOrderExists：支付通知只携带订单号，不能让其他商户改写回调地址与金额
+func (s *OrderStore) Save(result *models.DeepLinkResult) (*models.Order, error) {
+	return s.SaveWith(result, nil)
+}
+
+// SaveWith 同 Save，冲突检查通过后先调用 commit（可为 nil），成功才登记订单
+// 检查、commit 与登记在同一把锁内完成，commit 期间其他订单操作会等待
+func (s *OrderStore) SaveWith(result *models.DeepLinkResult, commit func() error) (*models.Order, error) {
+	order, err := models.NewOrder(result, s.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.orders[order.OrderID]; ok {
+		if existing.MerchantID != order.MerchantID {
+			return nil, models.ErrOrderExists.Errorf(models.Params{"orderId": order.OrderID}, "%s (属于其他商户)", order.OrderID)
+		}
+		switch existing.Status {
+		case models.OrderCreated, models.OrderLinkOpened, models.OrderExpired:
+		default:
+			return nil, models.ErrOrderExists.Errorf(models.Params{"orderId": order.OrderID}, "%s (%s)", order.OrderID, existing.Status)
+		}
+	}
+	if commit != nil {
+		if err := commit(); err != nil {
+			return nil, err
+		}
+	}
+	s.orders[order.OrderID] = order
+	return order.Clone(), nil
+}
+
+// Get 返回订单快照
+func (s *OrderStore) Get(orderID string) (*models.Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	order, err := s.lookup(orderID)
+	if err != nil {
+		return nil, err
+	}
+	return order.Clone(), nil
+}
+
+// Open 用户打开支付链接：created → link_opened，重复打开不再转换
+// 订单已过期返回 ErrOrderExpired，已支付 / 失败返回 ErrOrderClosed
+func (s *OrderStore) Open(orderID string) (*models.Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	order, err := s.lookup(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch order.Status {
+	case models.OrderCreated:
+		if err := order.Transition(models.OrderLinkOpened, models.SourceRedirect, "", s.now()); err != nil {
+			return nil, err
+		}
+		s.publish(order)
+	case models.OrderExpired:
+		return nil, models.ErrOrderExpired.Errorf(models.Params{"orderId": orderID}, "%s", orderID)
+	case models.OrderPaid, models.OrderFailed:
+		return nil, models.ErrOrderClosed.Errorf(models.Params{"orderId": orderID}, "%s (%s)", orderID, order.Status)
+	}
+	return order.Clone(), nil
+}
+
+// ApplyNotification 按支付通知更新订单状态
+// 重复通知（同一幂等键，或状态未变化）与迟到的通知（如已支付后的 PENDING）不再转换，duplicate 返回 true
+func (s *OrderStore) ApplyNotification(n models.PaymentNotification) (order *models.Order, duplicate bool, err error) {
+	to, err := n.OrderStatus()
+	if err != nil {
+		return nil, false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, err := s.lookup(n.OrderID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	key := n.Key()
+	if s.processed[n.OrderID][key] || current.Status == to || to.Precedes(current.Status) {
+		return current.Clone(), true, nil
+	}
+
+	if n.Amount != "" && current.Amount != "" {
+		notified, err := models.ParseAmount(n.Amount)
+		if err != nil {
+			return nil, false, err
+		}
+		if expected, _ := models.ParseAmount(current.Amount); notified != expected {
+			return nil, false, models.ErrAmountMismatch.Errorf(models.Params{"amount": notified.String(), "qrAmount": expected.String()},
+				"通知金额 %s, 订单金额 %s", notified, expected)
+		}
+	}
+
+	at := n.Timestamp
+	if at.IsZero() {
+		at = s.now()
+	}
+	if err := current.Transition(to, models.SourceNotify, n.NotificationID, at); err != nil {
+		return nil, false, err
+	}
+	if n.TransactionID != "" {
+		current.TransactionID = n.TransactionID
+	}
+	if s.processed[n.OrderID] == nil {
+		s.processed[n.OrderID] = make(map[string]bool)
+	}
+	s.processed[n.OrderID][key] = true
+	s.publish(current)
+	return current.Clone(), false, nil
+}
+
+// ExpireOverdue 将所有超过有效期的订单转为 expired，返回被过期的订单
+func (s *OrderStore) ExpireOverdue() []*models.Order {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	var expired []*models.Order
+	for _, order := range s.orders {
+		if s.expire(order, now) {
+			expired = append(expired, order.Clone())
+		}
+	}
+	return expired
+}
+
+// Prune 移除进入终态超过保留时间的订单及其通知幂等键，返回移除的订单数
+func (s *OrderStore) Prune() int {
+	if s.retention <= 0 {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	pruned := 0
+	for id, order := range s.orders {
+		if order.Status.Final() && now.Sub(order.UpdatedAt) > s.retention {
+			delete(s.orders, id)
+			delete(s.processed, id)
+			pruned++
+		}
+	}
+	return pruned
+}
+
+// lookup 查找订单并按需过期，调用方需持有锁
+func (s *OrderStore) lookup(orderID string) (*models.Order, error) {
+	order, ok := s.orders[orderID]
+	if !ok {
+		return nil, models.ErrOrderNotFound.Errorf(models.Params{"orderId": orderID}, "%s", orderID)
+	}
+	s.expire(order, s.now())
+	return order, nil
+}
+
+// expire 订单超过有效期时转为 expired
+func (s *OrderStore) expire(order *models.Order, now time.Time) bool {
+	if !order.Overdue(now) {
+		return false
+	}
+	if order.Transition(models.OrderExpired, models.SourceExpiry, "", *order.ExpiresAt) != nil {
+		return false
+	}
+	s.publish(order)
+	return true
+}
+
+// publish 发布订单当前状态对应的事件，调用方需持有锁（EventSink 不阻塞）
+func (s *OrderStore) publish(order *models.Order) {
+	if s.events == nil {
+		return
+	}
+	if e, ok := models.OrderEvent(order.Clone()); ok {
+		s.events.Publish(e)
+	}
+}
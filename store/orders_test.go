@@ -0,0 +1,100 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// result 构造带订单号的生成结果
+func result(orderID, merchantID, amount, redirectURL string) *models.DeepLinkResult {
+	return &models.DeepLinkResult{
+		Success:  true,
+		DeepLink: "gcash://com.mynt.gcash/app/006300000800?orderId=" + orderID,
+		Resolved: &models.ResolvedOptions{
+			DeepLinkOptions:     models.DeepLinkOptions{OrderID: orderID, MerchantID: merchantID, OrderAmount: amount},
+			MerchantRedirectURL: redirectURL,
+		},
+		GeneratedAt: time.Now(),
+	}
+}
+
+func TestSaveCrossMerchant(t *testing.T) {
+	s := NewOrderStore()
+	if _, err := s.Save(result("ORDER-X", "M-A", "100.00", "https://a.example/{status}")); err != nil {
+		t.Fatalf("保存订单失败: %v", err)
+	}
+
+	// 同一商户尚未支付时可以用新链接覆盖
+	if _, err := s.Save(result("ORDER-X", "M-A", "120.00", "https://a.example/{status}")); err != nil {
+		t.Fatalf("同一商户覆盖应成功: %v", err)
+	}
+
+	// 其他商户复用订单号：created / link_opened / expired 均不能覆盖
+	for _, step := range []func(){
+		func() {},
+		func() { s.Open("ORDER-X") },
+		func() { s.SetClock(func() time.Time { return time.Now().Add(time.Hour) }); s.ExpireOverdue() },
+	} {
+		step()
+		if _, err := s.Save(result("ORDER-X", "M-B", "1.00", "https://b.example/{status}")); !errors.Is(err, models.ErrOrderExists) {
+			t.Errorf("其他商户复用订单号应返回 ErrOrderExists, got %v", err)
+		}
+		order, err := s.Get("ORDER-X")
+		if err != nil {
+			t.Fatalf("查询订单失败: %v", err)
+		}
+		if order.MerchantID != "M-A" || order.Amount != "120.00" || order.RedirectURL != "https://a.example/{status}" {
+			t.Errorf("订单被其他商户覆盖: %+v", order)
+		}
+	}
+}
+
+func TestExpireAndPrune(t *testing.T) {
+	// 超时过期；过期后仍接受支付成功通知（逾期到账），迟到的 pending 忽略
+	now := time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC)
+	orders := NewOrderStore()
+	orders.SetTTL(10 * time.Minute)
+	orders.SetClock(func() time.Time { return now })
+	r := result("ORDER-EXP", "", "", "")
+	r.GeneratedAt = now
+	if _, err := orders.Save(r); err != nil {
+		t.Fatalf("保存订单失败: %v", err)
+	}
+
+	now = now.Add(11 * time.Minute)
+	if expired := orders.ExpireOverdue(); len(expired) != 1 || expired[0].Status != models.OrderExpired {
+		t.Fatalf("订单应过期: %+v", expired)
+	}
+	if _, err := orders.Open("ORDER-EXP"); !errors.Is(err, models.ErrOrderExpired) {
+		t.Errorf("过期订单不能打开, got %v", err)
+	}
+	if order, duplicate, err := orders.ApplyNotification(models.PaymentNotification{OrderID: "ORDER-EXP", Status: "PENDING"}); err != nil || !duplicate || order.Status != models.OrderExpired {
+		t.Errorf("过期订单不应转为 pending: %v %v", order, err)
+	}
+	order, _, err := orders.ApplyNotification(models.PaymentNotification{OrderID: "ORDER-EXP", Status: "SUCCESS", TransactionID: "TX-LATE"})
+	if err != nil || order.Status != models.OrderPaid {
+		t.Fatalf("逾期到账应转为 paid: %v", err)
+	}
+	want := []models.OrderStatus{models.OrderCreated, models.OrderExpired, models.OrderPaid}
+	for i, tr := range order.History {
+		if i >= len(want) || tr.To != want[i] {
+			t.Fatalf("状态历史错误: %+v", order.History)
+		}
+	}
+
+	// 终态订单超过保留时间后连同通知幂等键一起移除
+	orders.SetRetention(time.Hour)
+	if pruned := orders.Prune(); pruned != 0 {
+		t.Errorf("未超过保留时间不应移除, got %d", pruned)
+	}
+	now = now.Add(2 * time.Hour)
+	if pruned := orders.Prune(); pruned != 1 {
+		t.Errorf("应移除 1 个订单, got %d", pruned)
+	}
+	if _, err := orders.Get("ORDER-EXP"); !errors.Is(err, models.ErrOrderNotFound) {
+		t.Errorf("订单应已移除, got %v", err)
+	}
+}
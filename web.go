@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/qinyuanmao/gcash-deeplink/httperr"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// publicFiles 编译进程序的 Web 界面，程序可在任意工作目录运行
+//
+//go:embed public
+var publicFiles embed.FS
+
+// 静态文件缓存策略：HTML 每次按 ETag 重新验证，保证发布后立即生效；其他资源缓存一天
+const (
+	htmlCacheControl  = "no-cache"
+	assetCacheControl = "public, max-age=86400"
+)
+
+// spaIndex SPA 入口页
+const spaIndex = "index.html"
+
+// staticHandler Web 界面：dir 非空时从该目录读取（覆盖内置页面，修改后无需重新编译），否则使用内置的 public/
+// 不带扩展名且不存在的路径回退到 index.html（SPA 前端路由）；/api/ 与 /v1/ 下的未知路径返回 JSON 404
+func staticHandler(dir string) http.Handler {
+	var fsys fs.FS
+	if dir != "" {
+		fsys = os.DirFS(dir)
+	} else {
+		fsys, _ = fs.Sub(publicFiles, "public") // 目录名为常量，不会失败
+	}
+	return &staticFiles{fsys: fsys}
+}
+
+type staticFiles struct {
+	fsys fs.FS
+}
+
+func (s *staticFiles) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/api/") || strings.HasPrefix(r.URL.Path, "/v1/") {
+		httperr.Write(w, r, http.StatusNotFound, models.CodeNotFound, "接口不存在: "+r.URL.Path)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		httperr.MethodNotAllowed(w, r, http.MethodGet, http.MethodHead)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" {
+		name = spaIndex
+	}
+	content, err := s.read(name)
+	if errors.Is(err, fs.ErrNotExist) && path.Ext(name) == "" {
+		name = spaIndex
+		content, err = s.read(name)
+	}
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, "读取静态文件失败", http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(content)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:8])+`"`)
+	if path.Ext(name) == ".html" {
+		w.Header().Set("Cache-Control", htmlCacheControl)
+	} else {
+		w.Header().Set("Cache-Control", assetCacheControl)
+	}
+	// 内置文件没有修改时间，依靠 ETag 处理 If-None-Match
+	http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(content))
+}
+
+// read 读取文件；目录视为不存在
+func (s *staticFiles) read(name string) ([]byte, error) {
+	info, err := fs.Stat(s.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fs.ErrNotExist
+	}
+	return fs.ReadFile(s.fsys, name)
+}
@@ -0,0 +1,360 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/qinyuanmao/gcash-deeplink/models"
+)
+
+// 推送错误
+var (
+	ErrDeliveryNotFound = errors.New("投递记录不存在")
+	ErrClosed           = errors.New("事件推送已关闭")
+)
+
+// 请求头
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// 默认重试参数
+const (
+	DefaultMaxAttempts = 5
+	DefaultBaseDelay   = time.Second
+	DefaultMaxDelay    = time.Minute
+)
+
+// DeliveryStatus 投递状态
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryDead      DeliveryStatus = "dead" // 多次失败，进入死信
+)
+
+// Delivery 一次事件投递
+type Delivery struct {
+	ID        string         `json:"id"`
+	URL       string         `json:"url"`
+	Event     models.Event   `json:"event"`
+	Status    DeliveryStatus `json:"status"`
+	Attempts  int            `json:"attempts"`
+	LastError string         `json:"lastError,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+
+	secret string
+}
+
+// DeadLettersResponse GET /v1/webhooks/dead-letters
+type DeadLettersResponse struct {
+	DeadLetters []Delivery `json:"deadLetters"`
+}
+
+// ReplayResponse POST /v1/webhooks/dead-letters/{id}/replay
+type ReplayResponse struct {
+	ID string `json:"id"`
+}
+
+// Dispatcher 将事件推送到订阅 URL：HMAC 签名、指数退避重试，多次失败进入死信
+// 实现 models.EventSink，Publish 异步投递不阻塞调用方
+type Dispatcher struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	Client      *http.Client
+
+	subscriptions []models.WebhookSubscription
+	redact        models.RedactionPolicy // 推送前的脱敏规则，零值使用默认规则
+
+	mu       sync.Mutex
+	dead     map[string]*Delivery
+	deadFile string     // 死信持久化文件，为空时死信只保存在内存，退出后丢失
+	saveMu   sync.Mutex // 串行化死信文件写入
+	closed   bool
+	wg       sync.WaitGroup
+	stop     chan struct{}
+	once     sync.Once
+}
+
+// NewDispatcher 创建事件推送器
+func NewDispatcher(subscriptions []models.WebhookSubscription) *Dispatcher {
+	return &Dispatcher{
+		MaxAttempts:   DefaultMaxAttempts,
+		BaseDelay:     DefaultBaseDelay,
+		MaxDelay:      DefaultMaxDelay,
+		Client:        &http.Client{Timeout: 10 * time.Second},
+		subscriptions: subscriptions,
+		dead:          make(map[string]*Delivery),
+		stop:          make(chan struct{}),
+	}
+}
+
+// SetRedaction 设置推送前的脱敏规则（见 models.Event.Redacted），默认 models.DefaultRedactionPolicy
+func (d *Dispatcher) SetRedaction(p models.RedactionPolicy) {
+	d.redact = p
+}
+
+// Publish 实现 models.EventSink，事件脱敏后向所有匹配的订阅异步投递
+func (d *Dispatcher) Publish(e models.Event) {
+	e = e.Redacted(d.redact)
+	for _, s := range d.subscriptions {
+		if !s.Matches(e) {
+			continue
+		}
+		now := time.Now()
+		d.start(&Delivery{
+			ID:        newDeliveryID(),
+			URL:       s.URL,
+			Event:     e,
+			Status:    DeliveryPending,
+			CreatedAt: now,
+			UpdatedAt: now,
+			secret:    s.Secret,
+		})
+	}
+}
+
+// DeadLetters 返回死信列表（按创建时间排序）
+func (d *Dispatcher) DeadLetters() []Delivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	list := make([]Delivery, 0, len(d.dead))
+	for _, dl := range d.dead {
+		list = append(list, *dl)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list
+}
+
+// Replay 将死信重新投递（重新计算重试次数）
+func (d *Dispatcher) Replay(id string) error {
+	d.mu.Lock()
+	dl, ok := d.dead[id]
+	if ok {
+		delete(d.dead, id)
+	}
+	d.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrDeliveryNotFound, id)
+	}
+	d.persist()
+
+	dl.Status = DeliveryPending
+	dl.Attempts = 0
+	dl.UpdatedAt = time.Now()
+	if !d.start(dl) {
+		return ErrClosed
+	}
+	return nil
+}
+
+// PersistDeadLetters 读取 path 中上次保存的死信，之后每次死信增减都立即写回该文件
+// 进程被强制结束时已进入死信的投递也不会丢失
+// 订阅已从配置中移除（无法签名）的死信不再恢复；文件不存在时视为空
+func (d *Dispatcher) PersistDeadLetters(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("读取死信文件失败: %w", err)
+	}
+	var saved []*Delivery
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &saved); err != nil {
+			return fmt.Errorf("死信文件格式错误: %w", err)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deadFile = path
+	for _, dl := range saved {
+		for _, s := range d.subscriptions {
+			if s.URL == dl.URL && s.Matches(dl.Event) {
+				dl.secret = s.Secret
+				d.dead[dl.ID] = dl
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Wait 等待所有进行中的投递完成
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// Close 停止等待中的重试（进行中的请求会完成），未投递成功的进入死信
+// 之后发布的事件直接进入死信；设置了 PersistDeadLetters 时再写回一次文件，失败时返回错误
+func (d *Dispatcher) Close() error {
+	d.mu.Lock()
+	d.closed = true
+	d.mu.Unlock()
+	d.once.Do(func() { close(d.stop) })
+	d.wg.Wait()
+	return d.saveDeadLetters()
+}
+
+// start 异步投递；已关闭时放入死信并返回 false
+// closed 与 wg.Add 在同一把锁内检查，Close 开始等待后不会再有新的投递
+func (d *Dispatcher) start(dl *Delivery) bool {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		d.bury(dl, ErrClosed)
+		return false
+	}
+	d.wg.Add(1)
+	d.mu.Unlock()
+
+	go func() {
+		defer d.wg.Done()
+		d.deliver(dl)
+	}()
+	return true
+}
+
+// persist 死信变化后立即写回文件；失败时记录日志，由下一次写入或 Close 重试
+func (d *Dispatcher) persist() {
+	if err := d.saveDeadLetters(); err != nil {
+		slog.Warn("写入死信文件失败", "error", err)
+	}
+}
+
+// saveDeadLetters 将当前死信原子写回文件（权限 0600）
+// 写入串行执行，每次都取最新的死信列表，并发写入不会用旧快照覆盖新快照
+func (d *Dispatcher) saveDeadLetters() error {
+	d.saveMu.Lock()
+	defer d.saveMu.Unlock()
+	d.mu.Lock()
+	path := d.deadFile
+	d.mu.Unlock()
+	if path == "" {
+		return nil
+	}
+	return writeDeadLetters(path, d.DeadLetters())
+}
+
+// writeDeadLetters 先写临时文件再重命名
+func writeDeadLetters(path string, list []Delivery) error {
+	raw, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("写入死信文件失败: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("写入死信文件失败: %w", err)
+	}
+	return nil
+}
+
+// deliver 投递并按指数退避重试
+func (d *Dispatcher) deliver(dl *Delivery) {
+	body, err := json.Marshal(dl.Event)
+	if err != nil {
+		d.bury(dl, err)
+		return
+	}
+
+	for {
+		dl.Attempts++
+		err = d.send(dl, body)
+		dl.UpdatedAt = time.Now()
+		if err == nil {
+			dl.Status = DeliveryDelivered
+			dl.LastError = ""
+			return
+		}
+		if dl.Attempts >= d.MaxAttempts {
+			d.bury(dl, err)
+			return
+		}
+
+		select {
+		case <-time.After(d.backoff(dl.Attempts)):
+		case <-d.stop:
+			d.bury(dl, err)
+			return
+		}
+	}
+}
+
+// send 发送一次签名请求，非 2xx 视为失败
+func (d *Dispatcher) send(dl *Delivery, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, dl.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(dl.Event.Type))
+	req.Header.Set(HeaderDelivery, dl.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(dl.secret, timestamp, body))
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("订阅方返回 %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// backoff 第 attempt 次失败后的等待时间: BaseDelay * 2^(attempt-1)，不超过 MaxDelay
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	delay := d.BaseDelay << (attempt - 1)
+	if delay <= 0 || (d.MaxDelay > 0 && delay > d.MaxDelay) {
+		delay = d.MaxDelay
+	}
+	return delay
+}
+
+// bury 放入死信并写回死信文件
+func (d *Dispatcher) bury(dl *Delivery, err error) {
+	dl.Status = DeliveryDead
+	dl.LastError = err.Error()
+	d.mu.Lock()
+	d.dead[dl.ID] = dl
+	d.mu.Unlock()
+	d.persist()
+}
+
+// newDeliveryID 随机投递 ID
+func newDeliveryID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return "dlv_" + hex.EncodeToString(b)
+}
+
+// Sign 签名: HMAC-SHA256(secret, timestamp + "." + body) 的十六进制
+// 订阅方应校验签名并拒绝时间戳过旧的请求
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,223 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/qinyuanmao/gcash-deeplink/generator"
+	"github.com/qinyuanmao/gcash-deeplink/models"
+	"github.com/qinyuanmao/gcash-deeplink/store"
+	"github.com/qinyuanmao/gcash-deeplink/webhook"
+)
+
+func TestPublishRedactsEvent(t *testing.T) {
+	bodies := make(chan []byte, 2)
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- body
+	}))
+	defer subscriber.Close()
+
+	const qr = "00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ0503000"
+	deepLink := "gcash://com.mynt.gcash/app/006300000800?qrCode=" + qr
+	d := webhook.NewDispatcher([]models.WebhookSubscription{{URL: subscriber.URL, Secret: "whsec"}})
+	d.Publish(models.NewEvent(models.EventLinkGenerated, "M-1", "ORDER-R1", models.LinkGeneratedData{
+		DeepLink: deepLink,
+		Resolved: &models.ResolvedOptions{
+			DeepLinkOptions: models.DeepLinkOptions{QRCode: qr, OrderID: "ORDER-R1"},
+			AccountNumber:   "09171234567",
+			AccountName:     "JUAN DELA CRUZ",
+		},
+	}))
+	d.Publish(models.NewEvent(models.EventPaymentSucceeded, "M-1", "ORDER-R1", &models.Order{OrderID: "ORDER-R1", DeepLink: deepLink}))
+	d.Wait()
+
+	for i := 0; i < 2; i++ {
+		body := <-bodies
+		if strings.Contains(string(body), qr) || strings.Contains(string(body), "09171234567") {
+			t.Errorf("推送内容未脱敏: %s", body)
+		}
+	}
+
+	// deepLink 替换为与审计记录相同的 deepLinkHash
+	var e struct {
+		Data models.LinkGeneratedData `json:"data"`
+	}
+	redacted := models.NewEvent(models.EventLinkGenerated, "M-1", "", models.LinkGeneratedData{DeepLink: deepLink}).Redacted(models.DefaultRedactionPolicy())
+	raw, _ := json.Marshal(redacted)
+	json.Unmarshal(raw, &e)
+	if e.Data.DeepLink != "" || e.Data.DeepLinkHash != models.HashValue(deepLink) {
+		t.Errorf("deepLink 应替换为 SHA-256: %+v", e.Data)
+	}
+}
+
+func TestOutboundWebhooks(t *testing.T) {
+	var mu sync.Mutex
+	received := map[models.EventType]int{}
+	failures := 0
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(webhook.HeaderSignature) != webhook.Sign("whsec", r.Header.Get(webhook.HeaderTimestamp), body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		// 前 3 次 payment.failed 投递失败
+		if r.Header.Get(webhook.HeaderEvent) == string(models.EventPaymentFailed) && failures < 3 {
+			failures++
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		received[models.EventType(r.Header.Get(webhook.HeaderEvent))]++
+	}))
+	defer subscriber.Close()
+
+	d := webhook.NewDispatcher([]models.WebhookSubscription{
+		{MerchantID: "M-1", URL: subscriber.URL, Secret: "whsec"},
+		{MerchantID: "M-OTHER", URL: subscriber.URL, Secret: "whsec"},
+	})
+	d.MaxAttempts = 3
+	d.BaseDelay = time.Millisecond
+	defer d.Close()
+
+	g := generator.NewDeepLinkGenerator()
+	g.SetEventSink(d)
+	orders := store.NewOrderStore()
+	orders.SetEventSink(d)
+
+	qrCode := "00020101021228530011ph.ppmi.p2m0111SRCPPHM2XXX0312MRCHNT-4H3TZ05030005204519953036085406100.005802PH5925SOCMED DIGITAL MARKETING 6010MakatiCity62650010ph.starpay0315SOCMED DIGITAL 0509OR#1Z1CSC0708TodayPay0803***88290012ph.ppmi.qrph0109OR#1Z1CSC63040275"
+	for _, orderID := range []string{"ORDER-W1", "ORDER-W2"} {
+		result, err := g.GenerateWithValidation(qrCode, &models.DeepLinkOptions{OrderID: orderID, MerchantID: "M-1"})
+		if err != nil {
+			t.Fatalf("生成失败: %v", err)
+		}
+		if _, err := orders.Save(result); err != nil {
+			t.Fatalf("保存订单失败: %v", err)
+		}
+	}
+	if _, err := orders.Open("ORDER-W1"); err != nil {
+		t.Fatalf("打开链接失败: %v", err)
+	}
+	orders.ApplyNotification(models.PaymentNotification{OrderID: "ORDER-W1", Status: "SUCCESS"})
+	orders.ApplyNotification(models.PaymentNotification{OrderID: "ORDER-W2", Status: "FAILED"})
+	d.Wait()
+
+	// payment.failed 重试 3 次后进入死信
+	dead := d.DeadLetters()
+	if len(dead) != 1 || dead[0].Event.Type != models.EventPaymentFailed || dead[0].Attempts != 3 {
+		t.Fatalf("死信错误: %+v", dead)
+	}
+	if err := d.Replay(dead[0].ID); err != nil {
+		t.Fatalf("重放失败: %v", err)
+	}
+	d.Wait()
+	if len(d.DeadLetters()) != 0 {
+		t.Errorf("重放成功后死信应为空: %+v", d.DeadLetters())
+	}
+	if err := d.Replay(dead[0].ID); !errors.Is(err, webhook.ErrDeliveryNotFound) {
+		t.Errorf("重复重放应失败, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := map[models.EventType]int{
+		models.EventLinkGenerated:    2,
+		models.EventLinkOpened:       1,
+		models.EventPaymentSucceeded: 1,
+		models.EventPaymentFailed:    1,
+	}
+	for eventType, n := range want {
+		if received[eventType] != n {
+			t.Errorf("%s 收到 %d 次, want %d (%v)", eventType, received[eventType], n, received)
+		}
+	}
+}
+
+func TestWebhookClose(t *testing.T) {
+	var accept atomic.Bool
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !accept.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer subscriber.Close()
+	subs := []models.WebhookSubscription{{MerchantID: "M-1", URL: subscriber.URL, Secret: "whsec"}}
+	path := filepath.Join(t.TempDir(), "dead-letters.json")
+
+	d := webhook.NewDispatcher(subs)
+	d.MaxAttempts = 1
+	if err := d.PersistDeadLetters(path); err != nil {
+		t.Fatalf("读取死信文件失败: %v", err)
+	}
+	d.Publish(models.Event{Type: models.EventPaymentFailed, MerchantID: "M-1", OrderID: "ORDER-C1"})
+	d.Wait()
+
+	// 进入死信时立即写入文件，不依赖 Close（进程被强制结束也不丢失）
+	beforeClose := webhook.NewDispatcher(subs)
+	if err := beforeClose.PersistDeadLetters(path); err != nil {
+		t.Fatalf("读取死信文件失败: %v", err)
+	}
+	if got := beforeClose.DeadLetters(); len(got) != 1 || got[0].Event.OrderID != "ORDER-C1" {
+		t.Fatalf("死信应在进入时写入文件: %+v", got)
+	}
+
+	if err := d.Close(); err != nil {
+		t.Fatalf("关闭失败: %v", err)
+	}
+
+	// 关闭后发布的事件直接进入死信，重放返回 ErrClosed 且死信保留
+	d.Publish(models.Event{Type: models.EventPaymentFailed, MerchantID: "M-1", OrderID: "ORDER-C2"})
+	dead := d.DeadLetters()
+	if len(dead) != 2 || dead[1].LastError != webhook.ErrClosed.Error() {
+		t.Fatalf("关闭后的死信错误: %+v", dead)
+	}
+	if err := d.Replay(dead[0].ID); !errors.Is(err, webhook.ErrClosed) {
+		t.Errorf("关闭后重放应返回 ErrClosed, got %v", err)
+	}
+	if len(d.DeadLetters()) != 2 {
+		t.Errorf("重放失败后死信应保留: %+v", d.DeadLetters())
+	}
+
+	// 新的推送器从文件恢复全部死信（含关闭后进入的），签名密钥取自当前订阅
+	restored := webhook.NewDispatcher(subs)
+	if err := restored.PersistDeadLetters(path); err != nil {
+		t.Fatalf("恢复死信失败: %v", err)
+	}
+	got := restored.DeadLetters()
+	if len(got) != 2 || got[0].ID != dead[0].ID || got[0].Event.OrderID != "ORDER-C1" || got[1].Event.OrderID != "ORDER-C2" {
+		t.Errorf("恢复的死信错误: %+v", got)
+	}
+
+	// 重放后死信从文件中移除
+	accept.Store(true)
+	if err := restored.Replay(got[1].ID); err != nil {
+		t.Fatalf("重放失败: %v", err)
+	}
+	restored.Wait()
+	reloaded := webhook.NewDispatcher(subs)
+	if err := reloaded.PersistDeadLetters(path); err != nil {
+		t.Fatalf("恢复死信失败: %v", err)
+	}
+	if left := reloaded.DeadLetters(); len(left) != 1 || left[0].ID != dead[0].ID {
+		t.Errorf("重放后的死信文件错误: %+v", left)
+	}
+	restored.Close()
+	// 订阅已移除的死信不再恢复
+	orphan := webhook.NewDispatcher(nil)
+	if err := orphan.PersistDeadLetters(path); err != nil {
+		t.Fatalf("恢复死信失败: %v", err)
+	}
+	if len(orphan.DeadLetters()) != 0 {
+		t.Errorf("订阅已移除的死信不应恢复: %+v", orphan.DeadLetters())
+	}
+}